@@ -115,6 +115,7 @@ func NewModel() Model {
 					Align(lipgloss.Left),
 			).
 			SortByAsc(columnKeyID).
+			WithClipboardCopy(true).
 			WithMissingDataIndicatorStyled(table.StyledCell{
 				Style: lipgloss.NewStyle().Foreground(lipgloss.Color("#faa")),
 				Data:  "<ない>",
@@ -175,6 +176,7 @@ func (m Model) View() string {
 	body.WriteString("A (chaotic) table demo with all features enabled!\n")
 	body.WriteString("Press left/right or page up/down to move pages\n")
 	body.WriteString("Press 'i' to toggle the header visibility\n")
+	body.WriteString("Press 'y' to copy the highlighted row, or 'Y' to copy the selected rows\n")
 	body.WriteString("Press space/enter to select a row, q or ctrl+c to quit\n")
 
 	selectedIDs := []string{}
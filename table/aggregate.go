@@ -0,0 +1,166 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// AggregateFunc combines the values of a column across many rows into a single
+// value, such as a sum.  StyledCell values are unwrapped, and rows that don't
+// have any data for the column are left out.
+type AggregateFunc func(values []any) any
+
+// AggregateCount returns the number of values.
+func AggregateCount(values []any) any {
+	return len(values)
+}
+
+// AggregateSum returns the sum of all values that are numbers as a float64,
+// ignoring any other values.
+func AggregateSum(values []any) any {
+	sum := 0.0
+
+	for _, value := range values {
+		if number, ok := asNumber(value); ok {
+			sum += number
+		}
+	}
+
+	return sum
+}
+
+// AggregateAvg returns the average (mean) of all values that are numbers as a
+// float64, ignoring any other values.  Returns nil if there are no numbers.
+func AggregateAvg(values []any) any {
+	sum := 0.0
+	count := 0
+
+	for _, value := range values {
+		if number, ok := asNumber(value); ok {
+			sum += number
+			count++
+		}
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	return sum / float64(count)
+}
+
+// AggregateMin returns the smallest value.  If any values are numbers, other
+// values are ignored and numbers are compared by value.  Otherwise values are
+// compared as strings.  Returns nil if there are no values.
+func AggregateMin(values []any) any {
+	return aggregateExtreme(values, func(cmp int) bool { return cmp < 0 })
+}
+
+// AggregateMax returns the largest value.  If any values are numbers, other
+// values are ignored and numbers are compared by value.  Otherwise values are
+// compared as strings.  Returns nil if there are no values.
+func AggregateMax(values []any) any {
+	return aggregateExtreme(values, func(cmp int) bool { return cmp > 0 })
+}
+
+// AggregateDistinct returns the number of different values, comparing values
+// by how they're displayed.
+func AggregateDistinct(values []any) any {
+	seen := make(map[string]bool, len(values))
+
+	for _, value := range values {
+		seen[fmt.Sprint(value)] = true
+	}
+
+	return len(seen)
+}
+
+// aggregateExtreme returns the value that's better than all others, where
+// better is given the result of comparing a value to the best so far.
+func aggregateExtreme(values []any, better func(cmp int) bool) any {
+	var (
+		best       any
+		bestNumber float64
+		hasNumbers bool
+	)
+
+	for _, value := range values {
+		if number, ok := asNumber(value); ok {
+			if !hasNumbers || better(compareFloats(number, bestNumber)) {
+				best = value
+				bestNumber = number
+			}
+
+			hasNumbers = true
+		}
+	}
+
+	if hasNumbers {
+		return best
+	}
+
+	for i, value := range values {
+		if i == 0 || better(strings.Compare(fmt.Sprint(value), fmt.Sprint(best))) {
+			best = value
+		}
+	}
+
+	return best
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// columnValues returns the values of the column in the given rows, for
+// passing to an AggregateFunc.
+func columnValues(rows []Row, columnKey string) []any {
+	values := make([]any, 0, len(rows))
+
+	for _, row := range rows {
+		value, exists := row.Data[columnKey]
+
+		if !exists {
+			continue
+		}
+
+		if styled, isStyled := value.(StyledCell); isStyled {
+			value = styled.Data
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// formatAggregate formats the result of an AggregateFunc for display, keeping
+// floats short.
+func formatAggregate(value any) string {
+	switch number := value.(type) {
+	case nil:
+		return ""
+
+	case float64:
+		if number == math.Trunc(number) {
+			return strconv.FormatFloat(number, 'f', -1, 64)
+		}
+
+		return strings.TrimRight(strconv.FormatFloat(number, 'f', 2, 64), "0")
+
+	case float32:
+		return formatAggregate(float64(number))
+	}
+
+	return fmt.Sprint(value)
+}
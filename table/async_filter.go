@@ -0,0 +1,284 @@
+package table
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const asyncFilterIndicator = "filtering…"
+
+type asyncFilterDebounceMsg struct {
+	tableID  uint32
+	queryKey string
+}
+
+type asyncFilterResultMsg struct {
+	tableID   uint32
+	queryKey  string
+	filter    string
+	sortOrder []SortColumn
+
+	// sourceRows are the rows that were filtered, to check which rows have
+	// changed since
+	sourceRows []Row
+	rows       []Row
+}
+
+var lastAsyncFilterTableID uint32
+
+// WithAsyncFiltering sets whether filtering and sorting are calculated in the
+// background instead of immediately, which keeps very large tables responsive
+// while typing a filter.  Changes are applied once no further changes have
+// been made for the given debounce duration, and the footer shows that the
+// table is filtering until the results arrive.  Any results that are out of
+// date by the time they arrive are thrown away.  Rows updated in the meantime
+// are filtered and sorted again as the results arrive, and if rows were added
+// or removed, the work starts again in the background with the current rows.
+//
+// The commands to do the work are returned by Init and Update, so changes such
+// as SortByAsc that are made outside of Update are applied on the next Update.
+func (m Model) WithAsyncFiltering(async bool, debounce time.Duration) Model {
+	m.asyncFiltering = async
+	m.asyncFilterDebounce = debounce
+	m.asyncFilterTableID = atomic.AddUint32(&lastAsyncFilterTableID, 1)
+	m.asyncFilterPendingKey = ""
+
+	// Start from whatever is currently applied
+	m.asyncAppliedFilter = m.filterTextInput.Value()
+	m.asyncAppliedSortOrder = m.GetColumnSorting()
+	m.asyncAppliedKey = m.queryKey()
+	m.visibleRowCacheUpdated = false
+
+	return m
+}
+
+// GetIsFiltering returns true if filtering or sorting is currently being
+// calculated in the background.
+func (m *Model) GetIsFiltering() bool {
+	return m.asyncFiltering && m.rowSource == nil && m.asyncAppliedKey != m.queryKey()
+}
+
+// appliedFilter returns the filter text that the visible rows are filtered by,
+// which may be behind the filter input when filtering asynchronously.
+func (m *Model) appliedFilter() string {
+	if m.asyncFiltering {
+		return m.asyncAppliedFilter
+	}
+
+	return m.filterTextInput.Value()
+}
+
+// appliedSortOrder returns the sort order that the visible rows are sorted by,
+// which may be behind the current sort order when sorting asynchronously.
+func (m *Model) appliedSortOrder() []SortColumn {
+	if m.asyncFiltering {
+		return m.asyncAppliedSortOrder
+	}
+
+	return m.sortOrder
+}
+
+// invalidateQuery marks the visible rows as out of date after the filter or
+// sorting has changed.  When filtering asynchronously the rows stay as they are
+// until the new results are calculated in the background.
+func (m *Model) invalidateQuery() {
+	if !m.asyncFiltering {
+		m.visibleRowCacheUpdated = false
+	}
+}
+
+// asyncFilterCmd returns a command to start filtering in the background if the
+// filter or sorting has changed and isn't already being worked on.
+func (m *Model) asyncFilterCmd() tea.Cmd {
+	if !m.asyncFiltering || m.rowSource != nil {
+		return nil
+	}
+
+	queryKey := m.queryKey()
+
+	if queryKey == m.asyncAppliedKey || queryKey == m.asyncFilterPendingKey {
+		return nil
+	}
+
+	m.asyncFilterPendingKey = queryKey
+
+	if m.asyncFilterDebounce <= 0 {
+		return m.calculateAsyncFilterCmd()
+	}
+
+	tableID := m.asyncFilterTableID
+
+	return tea.Tick(m.asyncFilterDebounce, func(time.Time) tea.Msg {
+		return asyncFilterDebounceMsg{
+			tableID:  tableID,
+			queryKey: queryKey,
+		}
+	})
+}
+
+// isCurrentAsyncFilter returns true if the given query is still the latest one,
+// ignoring anything from other tables or for outdated queries.  The pending key
+// is empty for work started by Init, which can't record it.
+func (m *Model) isCurrentAsyncFilter(tableID uint32, queryKey string) bool {
+	return tableID == m.asyncFilterTableID &&
+		queryKey == m.queryKey() &&
+		(m.asyncFilterPendingKey == "" || m.asyncFilterPendingKey == queryKey)
+}
+
+func (m *Model) handleAsyncFilterDebounce(msg asyncFilterDebounceMsg) tea.Cmd {
+	if !m.isCurrentAsyncFilter(msg.tableID, msg.queryKey) {
+		return nil
+	}
+
+	return m.calculateAsyncFilterCmd()
+}
+
+// calculateAsyncFilterCmd returns a command that filters and sorts a snapshot
+// of the current rows in the background.
+func (m *Model) calculateAsyncFilterCmd() tea.Cmd {
+	snapshot := *m
+
+	// Copy the rows so that changes such as selections made in the meantime
+	// don't touch the rows being worked on
+	snapshot.rows = make([]Row, len(m.rows))
+	copy(snapshot.rows, m.rows)
+
+	filter := m.filterTextInput.Value()
+	sortOrder := m.GetColumnSorting()
+	queryKey := m.queryKey()
+
+	return func() tea.Msg {
+		return asyncFilterResultMsg{
+			tableID:    snapshot.asyncFilterTableID,
+			queryKey:   queryKey,
+			filter:     filter,
+			sortOrder:  sortOrder,
+			sourceRows: snapshot.rows,
+			rows:       snapshot.calculateVisibleRows(snapshot.rows, filter, sortOrder),
+		}
+	}
+}
+
+func (m *Model) handleAsyncFilterResult(msg asyncFilterResultMsg) tea.Cmd {
+	if !m.isCurrentAsyncFilter(msg.tableID, msg.queryKey) {
+		return nil
+	}
+
+	updated, ok := m.rowsUpdatedSince(msg.sourceRows)
+
+	if !ok || (len(updated) > 0 && !m.canMutateVisibleRowCache()) {
+		// The results can't be fixed up for the rows that changed in the
+		// meantime, so start again in the background with the current rows
+		m.asyncFilterPendingKey = msg.queryKey
+
+		return m.calculateAsyncFilterCmd()
+	}
+
+	m.asyncFilterPendingKey = ""
+	m.asyncAppliedFilter = msg.filter
+	m.asyncAppliedSortOrder = msg.sortOrder
+	m.asyncAppliedKey = msg.queryKey
+
+	m.refreshAsyncFilterRows(msg, updated)
+
+	m.clampRowCursor()
+
+	return nil
+}
+
+// refreshAsyncFilterRows sets the visible rows from the results, swapping in
+// the current version of each row so that changes such as selections made
+// while filtering aren't lost.  The given rows were updated while filtering,
+// so they're checked against the filter again and moved to where they sort
+// now.
+func (m *Model) refreshAsyncFilterRows(msg asyncFilterResultMsg, updated []int) {
+	rowCursorIndex := m.rowCursorIndex
+
+	m.visibleRowCache = make([]Row, len(msg.rows))
+	copy(m.visibleRowCache, msg.rows)
+
+	for _, rowsIndex := range updated {
+		m.removeFromVisibleRowCache(msg.sourceRows[rowsIndex], rowsIndex)
+
+		if current := m.rows[rowsIndex]; m.isRowVisibleWithFilter(current) {
+			m.insertIntoVisibleRowCache(current, rowsIndex)
+		}
+	}
+
+	// The cursor is clamped to the new results afterwards
+	m.rowCursorIndex = rowCursorIndex
+
+	currentRows := make(map[uint32]Row, len(m.rows))
+
+	walkRowTree(m.rows, func(row Row) {
+		currentRows[row.id] = row
+	})
+
+	for i, row := range m.visibleRowCache {
+		current := currentRows[row.id]
+
+		// These are worked out for how the row is shown
+		current.expanded = row.expanded
+		current.depth = row.depth
+
+		m.visibleRowCache[i] = current
+	}
+
+	m.setVisibleRowCache(m.visibleRowCache)
+}
+
+// rowsUpdatedSince compares the rows with an earlier copy of them, returning
+// the indexes of the top level rows whose data has been replaced since.
+// Returns false if rows have been added, removed, reordered, expanded, or
+// collapsed, or if any child rows have changed.
+func (m *Model) rowsUpdatedSince(previous []Row) ([]int, bool) {
+	if len(previous) != len(m.rows) {
+		return nil, false
+	}
+
+	var updated []int
+
+	for i, row := range m.rows {
+		was := previous[i]
+
+		if row.id != was.id || row.expanded != was.expanded || !sameRowTrees(row.children, was.children) {
+			return nil, false
+		}
+
+		if !sameRowData(row.Data, was.Data) {
+			updated = append(updated, i)
+		}
+	}
+
+	return updated, true
+}
+
+// sameRowTrees returns true if the rows have the same structure, expansion,
+// and data.
+func sameRowTrees(rows []Row, previous []Row) bool {
+	if len(rows) != len(previous) {
+		return false
+	}
+
+	for i, row := range rows {
+		was := previous[i]
+
+		if row.id != was.id ||
+			row.expanded != was.expanded ||
+			!sameRowData(row.Data, was.Data) ||
+			!sameRowTrees(row.children, was.children) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameRowData returns true if the rows share the same data, since rows are
+// replaced rather than having their data changed.
+func sameRowData(data RowData, previous RowData) bool {
+	return reflect.ValueOf(data).Pointer() == reflect.ValueOf(previous).Pointer()
+}
@@ -0,0 +1,240 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genAsyncFilterModel(debounce time.Duration) Model {
+	return New([]Column{
+		NewColumn("name", "Name", 12).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "apple"}),
+		NewRow(RowData{"name": "banana"}),
+		NewRow(RowData{"name": "cherry"}),
+	}).Filtered(true).Focused(true).WithAsyncFiltering(true, debounce)
+}
+
+func visibleNames(model Model) []string {
+	names := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		names = append(names, row.Data["name"].(string))
+	}
+
+	return names
+}
+
+func TestAsyncFilteringDebouncesInput(t *testing.T) {
+	model := genAsyncFilterModel(time.Millisecond)
+
+	// Typing in the filter input shouldn't filter immediately
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, visibleNames(model))
+	assert.True(t, model.GetIsFiltering())
+	assert.Contains(t, model.View(), "filtering…")
+
+	model = model.WithFilterInputValue("b")
+	model, firstCmd := model.Update(nil)
+
+	assert.NotNil(t, firstCmd)
+
+	model = model.WithFilterInputValue("an")
+	model, secondCmd := model.Update(nil)
+
+	assert.NotNil(t, secondCmd)
+
+	// The first debounce is out of date, so shouldn't do anything
+	model, cmd := model.Update(firstCmd())
+
+	assert.Nil(t, cmd)
+
+	model, calculateCmd := model.Update(secondCmd())
+
+	assert.NotNil(t, calculateCmd)
+
+	model, cmd = model.Update(calculateCmd())
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"banana"}, visibleNames(model))
+	assert.False(t, model.GetIsFiltering())
+	assert.NotContains(t, model.View(), "filtering…")
+}
+
+func TestAsyncFilteringDiscardsStaleResults(t *testing.T) {
+	model := genAsyncFilterModel(0)
+
+	model = model.WithFilterInputValue("a")
+	model, staleCmd := model.Update(nil)
+
+	model = model.WithFilterInputValue("ch")
+	model, currentCmd := model.Update(nil)
+
+	model, _ = model.Update(currentCmd())
+	model, _ = model.Update(staleCmd())
+
+	assert.Equal(t, []string{"cherry"}, visibleNames(model))
+	assert.False(t, model.GetIsFiltering())
+}
+
+func TestAsyncFilteringSorts(t *testing.T) {
+	model := genAsyncFilterModel(0).SortByDesc("name")
+
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, visibleNames(model))
+
+	cmd := model.Init()
+
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, []string{"cherry", "banana", "apple"}, visibleNames(model))
+}
+
+func TestAsyncFilteringKeepsChangesWhileFiltering(t *testing.T) {
+	model := genAsyncFilterModel(0).SelectableRows(true)
+
+	model = model.WithFilterInputValue("an")
+	model, cmd := model.Update(nil)
+
+	// Select apple while the filter is still being calculated
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, []string{"banana"}, visibleNames(model))
+
+	model = model.WithFilterInputValue("")
+	model, cmd = model.Update(nil)
+	model, _ = model.Update(cmd())
+
+	selected := model.SelectedRows()
+
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "apple", selected[0].Data["name"])
+}
+
+func TestAsyncFilteringRecalculatesIfRowsChanged(t *testing.T) {
+	model := genAsyncFilterModel(0)
+
+	model = model.WithFilterInputValue("an")
+	model, cmd := model.Update(nil)
+
+	model = model.WithRows([]Row{
+		NewRow(RowData{"name": "mango"}),
+		NewRow(RowData{"name": "kiwi"}),
+	})
+
+	model, cmd = model.Update(cmd())
+
+	// The results are for the old rows, so the new rows are filtered next
+	assert.NotNil(t, cmd)
+	assert.True(t, model.GetIsFiltering())
+
+	model, cmd = model.Update(cmd())
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"mango"}, visibleNames(model))
+	assert.False(t, model.GetIsFiltering())
+}
+
+func TestAsyncFilteringRestartsInBackgroundIfRowsInserted(t *testing.T) {
+	model := genAsyncFilterModel(0)
+
+	model = model.WithFilterInputValue("an")
+	model, cmd := model.Update(nil)
+
+	model = model.InsertRows(NewRow(RowData{"name": "mango"}))
+
+	model, cmd = model.Update(cmd())
+
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"apple", "banana", "cherry", "mango"}, visibleNames(model))
+
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, []string{"banana", "mango"}, visibleNames(model))
+	assert.False(t, model.GetIsFiltering())
+}
+
+func TestAsyncFilteringRechecksRowsUpdatedWhileFiltering(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 12).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "apple"}).WithID("a"),
+		NewRow(RowData{"name": "banana"}).WithID("b"),
+		NewRow(RowData{"name": "cherry"}).WithID("c"),
+		NewRow(RowData{"name": "mango"}).WithID("m"),
+	}).Filtered(true).Focused(true).WithAsyncFiltering(true, 0)
+
+	model = model.SortByDesc("name").WithFilterInputValue("an")
+	model, cmd := model.Update(nil)
+
+	// Banana no longer matches, and cherry now does and sorts first
+	model = model.UpdateRow(NewRow(RowData{"name": "blueberry"}).WithID("b"))
+	model = model.UpdateRow(NewRow(RowData{"name": "orange"}).WithID("c"))
+
+	model, cmd = model.Update(cmd())
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"orange", "mango"}, visibleNames(model))
+	assert.False(t, model.GetIsFiltering())
+}
+
+func TestAsyncFilteringUsesResultsForUnchangedTrees(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 12).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "fruit"}).WithChildren(
+			NewRow(RowData{"name": "apple"}),
+			NewRow(RowData{"name": "banana"}),
+		),
+		NewRow(RowData{"name": "veg"}).WithChildren(
+			NewRow(RowData{"name": "carrot"}),
+		),
+	}).Filtered(true).Focused(true).SelectableRows(true).WithAsyncFiltering(true, 0)
+
+	model = model.WithFilterInputValue("an")
+	model, cmd := model.Update(nil)
+
+	// Selecting doesn't change which rows match
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	model, cmd = model.Update(cmd())
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"fruit", "banana"}, visibleNames(model))
+	assert.True(t, model.GetVisibleRows()[0].selected)
+	assert.Equal(t, 1, model.GetVisibleRows()[1].Depth())
+
+	// Expanding changes which rows are shown, so it's filtered again
+	model = model.WithFilterInputValue("")
+	model, cmd = model.Update(nil)
+
+	model = model.WithAllRowsExpanded(true)
+
+	model, cmd = model.Update(cmd())
+
+	assert.NotNil(t, cmd)
+
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, []string{"fruit", "apple", "banana", "veg", "carrot"}, visibleNames(model))
+}
+
+func TestAsyncFilteringIgnoresOtherTables(t *testing.T) {
+	model := genAsyncFilterModel(0)
+	other := genAsyncFilterModel(0)
+
+	model = model.WithFilterInputValue("an")
+	other = other.WithFilterInputValue("an")
+	model, cmd := model.Update(nil)
+
+	other, _ = other.Update(cmd())
+
+	assert.True(t, other.GetIsFiltering())
+	assert.Len(t, visibleNames(other), 3)
+}
@@ -98,3 +98,26 @@ func BenchmarkPlainTableViews(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkUpsertRowsIntoLargeSortedTable(b *testing.B) {
+	const numRows = 100000
+
+	rows := make([]Row, 0, numRows)
+
+	for i := 0; i < numRows; i++ {
+		rows = append(rows, NewRow(RowData{"id": i, "name": fmt.Sprintf("row %d", i)}).WithID(fmt.Sprintf("%d", i)))
+	}
+
+	model := New([]Column{
+		NewColumn("id", "ID", 6),
+		NewColumn("name", "Name", 12).WithFiltered(true),
+	}).WithRows(rows).SortByDesc("id").Filtered(true)
+
+	model.GetVisibleRows()
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		model = model.UpsertRows(NewRow(RowData{"id": n % numRows, "name": "updated"}).WithID(fmt.Sprintf("%d", n%numRows)))
+	}
+}
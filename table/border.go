@@ -358,7 +358,7 @@ func (b *borderStyleRow) inherit(s lipgloss.Style) {
 //
 //nolint:nestif
 func (m Model) styleHeaders() borderStyleRow {
-	hasRows := len(m.GetVisibleRows()) > 0 || m.calculatePadding(0) > 0
+	hasRows := m.visibleRowCount() > 0 || m.calculatePadding(0) > 0 || m.summaryRow || m.hasPinnedRows()
 	singleColumn := len(m.columns) == 1
 	styles := borderStyleRow{}
 
@@ -406,6 +406,34 @@ func (m Model) styleHeaders() borderStyleRow {
 	return styles
 }
 
+// styleRowWithTopDivider adds a divider above a row's borders to separate it
+// from the rows above, such as for the summary row.
+func (b *Border) styleRowWithTopDivider(styles borderStyleRow, singleColumn bool) borderStyleRow {
+	withTop := func(original lipgloss.Style, topRight string) lipgloss.Style {
+		border := original.GetBorderStyle()
+
+		border.Top = b.Top
+		border.TopLeft = b.LeftJunction
+		border.TopRight = topRight
+
+		return original.Copy().BorderStyle(border).BorderTop(true)
+	}
+
+	if singleColumn {
+		styles.left = withTop(styles.left, b.RightJunction)
+		styles.inner = styles.left
+		styles.right = styles.left
+
+		return styles
+	}
+
+	styles.left = withTop(styles.left, b.InnerJunction)
+	styles.inner = withTop(styles.inner, b.InnerJunction)
+	styles.right = withTop(styles.right, b.RightJunction)
+
+	return styles
+}
+
 func (m Model) styleRows() (inner borderStyleRow, last borderStyleRow) {
 	if len(m.columns) == 1 {
 		inner.left = m.border.styleSingleColumnInner
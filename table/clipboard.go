@@ -0,0 +1,235 @@
+package table
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CopySerializerInput is the input to a CopySerializer.
+type CopySerializerInput struct {
+	// Columns are the columns being copied, in the order they're shown.  When
+	// copying a single cell, this is only the cell's column.
+	Columns []Column
+
+	// Rows are the rows being copied, in the order they're shown.
+	Rows []Row
+}
+
+// CopySerializer turns the cells being copied into the text that's put on the
+// clipboard.
+type CopySerializer func(input CopySerializerInput) (string, error)
+
+// CopiedMsg is returned by the command started by a copy key, with the OSC 52
+// escape sequence that puts the copied text on the clipboard.  By default the
+// command has already written Sequence to the output set by
+// WithClipboardOutput, and Err is any error from writing it.  If
+// WithClipboardManualWrite is set, Written is false and the app must write
+// Sequence to the terminal itself.
+type CopiedMsg struct {
+	Sequence string
+	Written  bool
+	Err      error
+}
+
+// copyCellReplacer replaces the characters that would break the rows and
+// cells apart in CopyAsTSV.
+var copyCellReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ")
+
+// osc52MaxScreenChunk is the longest part of an escape sequence that GNU
+// Screen passes through at once.
+const osc52MaxScreenChunk = 76
+
+// CopyAsTSV is a CopySerializer that writes each row on its own line with the
+// displayed text of each cell separated by tabs, without a header row.  When
+// copying a single cell, this is just the cell's text.
+func CopyAsTSV(input CopySerializerInput) (string, error) {
+	lines := make([]string, len(input.Rows))
+	cells := make([]string, len(input.Columns))
+
+	for i, row := range input.Rows {
+		for j, column := range input.Columns {
+			cells[j] = copyCellReplacer.Replace(exportText(column, row, false))
+		}
+
+		lines[i] = strings.Join(cells, "\t")
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// CopyAsJSON is a CopySerializer that writes each row as a JSON object of its
+// raw data keyed by column key.  A single row is written as an object, and
+// multiple rows as an array.
+func CopyAsJSON(input CopySerializerInput) (string, error) {
+	builder := strings.Builder{}
+
+	err := exportJSON(&builder, input.Columns, input.Rows, ExportOptions{Raw: true}, len(input.Rows) == 1)
+
+	return strings.TrimSuffix(builder.String(), "\n"), err
+}
+
+// WithClipboardCopy sets whether the user can copy the highlighted row, the
+// highlighted cell when the cell cursor is enabled, or the selected rows to
+// the system clipboard with the copy keys.  Copying returns a command that
+// writes an OSC 52 escape sequence to standard output, which most terminals
+// support even over SSH, wrapped to pass through tmux or GNU Screen when
+// running in them, and then returns a CopiedMsg.  The text is made by the
+// serializer set with WithCopySerializer, which defaults to CopyAsTSV.
+func (m Model) WithClipboardCopy(enabled bool) Model {
+	m.clipboardCopy = enabled
+
+	return m
+}
+
+// WithClipboardOutput sets where the clipboard escape sequence is written,
+// which defaults to standard output.  Set this to the same output given to
+// tea.WithOutput if the program renders somewhere else.
+func (m Model) WithClipboardOutput(output io.Writer) Model {
+	m.clipboardOutput = output
+
+	return m
+}
+
+// WithClipboardManualWrite sets whether copying leaves writing the escape
+// sequence to the app, such as to write it through the same synchronized
+// writer the program renders to.  If set, the copy keys return a command with
+// a CopiedMsg holding the sequence, and nothing is put on the clipboard until
+// the app writes it.
+func (m Model) WithClipboardManualWrite(manual bool) Model {
+	m.clipboardManualWrite = manual
+
+	return m
+}
+
+// WithCopySerializer sets the function that turns copied cells into text for
+// the clipboard, such as CopyAsJSON.  Set to nil to use CopyAsTSV.
+func (m Model) WithCopySerializer(serializer CopySerializer) Model {
+	m.copySerializer = serializer
+
+	return m
+}
+
+// osc52 returns the escape sequence that asks the terminal to put the text on
+// the clipboard, wrapped to pass through tmux or GNU Screen if term is the
+// value of TERM inside them and inTmux is true inside tmux.
+func osc52(text, term string, inTmux bool) string {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+
+	switch {
+	case inTmux:
+		return "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+
+	case strings.HasPrefix(term, "screen"):
+		// Screen limits how much is passed through at once
+		builder := strings.Builder{}
+
+		for len(sequence) > 0 {
+			chunk := sequence[:min(len(sequence), osc52MaxScreenChunk)]
+			sequence = sequence[len(chunk):]
+
+			builder.WriteString("\x1bP" + chunk + "\x1b\\")
+		}
+
+		return builder.String()
+	}
+
+	return sequence
+}
+
+// handleCopyKeypress copies to the clipboard if a copy key was pressed.
+func (m *Model) handleCopyKeypress(msg tea.KeyMsg) tea.Cmd {
+	if !m.clipboardCopy {
+		return nil
+	}
+
+	var input CopySerializerInput
+
+	switch {
+	case key.Matches(msg, m.keyMap.CopyRow):
+		highlighted := m.HighlightedRow()
+
+		if highlighted.Data == nil || highlighted.groupHeader != nil {
+			return nil
+		}
+
+		input = CopySerializerInput{Columns: m.exportColumns(), Rows: []Row{highlighted}}
+
+	case key.Matches(msg, m.keyMap.CopyCell):
+		column, ok := m.cursorColumn()
+		highlighted := m.HighlightedRow()
+
+		if !m.cellCursor || !ok || column.key == columnKeySelect || highlighted.Data == nil || highlighted.groupHeader != nil {
+			return nil
+		}
+
+		input = CopySerializerInput{Columns: []Column{column}, Rows: []Row{highlighted}}
+
+	case key.Matches(msg, m.keyMap.CopySelected):
+		selected := m.SelectedRows()
+
+		if len(selected) == 0 {
+			return nil
+		}
+
+		input = CopySerializerInput{Columns: m.exportColumns(), Rows: selected}
+
+	default:
+		return nil
+	}
+
+	return m.copyCmd(input)
+}
+
+// copyCmd serializes the input and returns a command that writes the escape
+// sequence that puts it on the clipboard, or that returns the sequence if the
+// app writes it.
+func (m *Model) copyCmd(input CopySerializerInput) tea.Cmd {
+	serializer := m.copySerializer
+
+	if serializer == nil {
+		serializer = CopyAsTSV
+	}
+
+	text, err := serializer(input)
+
+	if err != nil {
+		m.appendUserEvent(UserEventCopied{
+			RowCount: len(input.Rows),
+			Err:      err,
+		})
+
+		return nil
+	}
+
+	m.appendUserEvent(UserEventCopied{
+		Text:     text,
+		RowCount: len(input.Rows),
+	})
+
+	_, inTmux := os.LookupEnv("TMUX")
+	sequence := osc52(text, os.Getenv("TERM"), inTmux)
+
+	if m.clipboardManualWrite {
+		return func() tea.Msg {
+			return CopiedMsg{Sequence: sequence}
+		}
+	}
+
+	output := m.clipboardOutput
+
+	if output == nil {
+		output = os.Stdout
+	}
+
+	return func() tea.Msg {
+		// Written in a single write, so that it isn't split up by rendering
+		_, err := io.WriteString(output, sequence)
+
+		return CopiedMsg{Sequence: sequence, Written: err == nil, Err: err}
+	}
+}
@@ -0,0 +1,233 @@
+package table
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genClipboardModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8),
+		NewColumn("size", "Size", 8).WithCellFormatter(FormatBytes),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a\tb", "size": 2048}),
+		NewRow(RowData{"name": "c", "size": 1}),
+	}).WithClipboardCopy(true).WithClipboardManualWrite(true).Focused(true)
+}
+
+// pressCopyKey sends the key and runs the resulting command, returning the
+// text put on the clipboard and the copy event if there was one.
+func pressCopyKey(t *testing.T, model Model, msg tea.KeyMsg) (Model, string, *UserEventCopied) {
+	t.Helper()
+
+	// Outside of any terminal multiplexer, restoring the environment after
+	t.Setenv("TMUX", "")
+	assert.NoError(t, os.Unsetenv("TMUX"))
+	t.Setenv("TERM", "xterm")
+
+	model, cmd := model.Update(msg)
+
+	sequence := ""
+
+	if cmd != nil {
+		copied, ok := cmd().(CopiedMsg)
+
+		if !ok {
+			t.Fatalf("expected a CopiedMsg")
+		}
+
+		sequence = copied.Sequence
+	}
+
+	var event *UserEventCopied
+
+	for _, e := range model.GetLastUpdateUserEvents() {
+		if copied, ok := e.(UserEventCopied); ok {
+			event = &copied
+		}
+	}
+
+	if sequence == "" {
+		return model, "", event
+	}
+
+	encoded := strings.TrimSuffix(strings.TrimPrefix(sequence, "\x1b]52;c;"), "\x07")
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+
+	assert.NoError(t, err)
+
+	return model, string(decoded), event
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestCopyAsTSV(t *testing.T) {
+	text, err := CopyAsTSV(CopySerializerInput{
+		Columns: []Column{NewColumn("a", "A", 5), NewColumn("b", "B", 5).WithFormatString("%.1f")},
+		Rows: []Row{
+			NewRow(RowData{"a": "x\ny", "b": 1.25}),
+			NewRow(RowData{"a": "z"}),
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "x y\t1.2\nz\t", text)
+}
+
+func TestCopyAsJSON(t *testing.T) {
+	columns := []Column{NewColumn("a", "A", 5), NewColumn("b", "B", 5)}
+
+	text, err := CopyAsJSON(CopySerializerInput{
+		Columns: columns,
+		Rows:    []Row{NewRow(RowData{"a": "x", "b": 1})},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":"x","b":1}`, text)
+
+	text, err = CopyAsJSON(CopySerializerInput{
+		Columns: columns,
+		Rows:    []Row{NewRow(RowData{"a": "x"}), NewRow(RowData{"b": 2})},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"a":"x","b":null},{"a":null,"b":2}]`, text)
+}
+
+func TestCopyRow(t *testing.T) {
+	model := genClipboardModel()
+
+	_, text, event := pressCopyKey(t, model, runeKey('y'))
+
+	assert.Equal(t, "a b\t2 KiB", text)
+
+	if event == nil {
+		t.Fatal("expected a copy event")
+	}
+
+	assert.Equal(t, UserEventCopied{Text: "a b\t2 KiB", RowCount: 1}, *event)
+}
+
+func TestCopyDisabled(t *testing.T) {
+	model := genClipboardModel().WithClipboardCopy(false)
+
+	_, text, event := pressCopyKey(t, model, runeKey('y'))
+
+	assert.Empty(t, text)
+	assert.Nil(t, event)
+}
+
+func TestCopyCellRequiresCellCursor(t *testing.T) {
+	model := genClipboardModel()
+
+	_, text, _ := pressCopyKey(t, model, runeKey('c'))
+
+	assert.Empty(t, text)
+
+	model = model.WithCellCursor(true)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	_, text, event := pressCopyKey(t, model, runeKey('c'))
+
+	assert.Equal(t, "1 B", text)
+
+	if event == nil {
+		t.Fatal("expected a copy event")
+	}
+
+	assert.Equal(t, 1, event.RowCount)
+}
+
+func TestCopySelected(t *testing.T) {
+	model := genClipboardModel().SelectableRows(true).WithCopySerializer(CopyAsJSON)
+
+	_, text, event := pressCopyKey(t, model, runeKey('Y'))
+
+	assert.Empty(t, text)
+	assert.Nil(t, event)
+
+	model = model.WithRows([]Row{
+		NewRow(RowData{"name": "a", "size": 2048}).Selected(true),
+		NewRow(RowData{"name": "b", "size": 3}),
+		NewRow(RowData{"name": "c", "size": 1}).Selected(true),
+	})
+
+	_, text, event = pressCopyKey(t, model, runeKey('Y'))
+
+	assert.Equal(t, `[{"name":"a","size":2048},{"name":"c","size":1}]`, text)
+
+	if event == nil {
+		t.Fatal("expected a copy event")
+	}
+
+	assert.Equal(t, 2, event.RowCount)
+}
+
+func TestCopySerializerError(t *testing.T) {
+	serializerErr := errors.New("nope")
+	model := genClipboardModel().WithCopySerializer(func(CopySerializerInput) (string, error) {
+		return "", serializerErr
+	})
+
+	_, text, event := pressCopyKey(t, model, runeKey('y'))
+
+	assert.Empty(t, text)
+
+	if event == nil {
+		t.Fatal("expected a copy event")
+	}
+
+	assert.Equal(t, UserEventCopied{RowCount: 1, Err: serializerErr}, *event)
+}
+
+func TestCopyWritesToOutputByDefault(t *testing.T) {
+	t.Setenv("TMUX", "")
+	assert.NoError(t, os.Unsetenv("TMUX"))
+	t.Setenv("TERM", "xterm")
+
+	output := bytes.Buffer{}
+	model := genClipboardModel().WithClipboardManualWrite(false).WithClipboardOutput(&output)
+
+	_, cmd := model.Update(runeKey('y'))
+
+	if cmd == nil {
+		t.Fatal("expected a command to write to the clipboard")
+	}
+
+	assert.Empty(t, output.String(), "Should only write when the command runs")
+
+	copied, ok := cmd().(CopiedMsg)
+
+	if !ok {
+		t.Fatal("expected a CopiedMsg")
+	}
+
+	assert.True(t, copied.Written)
+	assert.NoError(t, copied.Err)
+	assert.Equal(t, copied.Sequence, output.String())
+	assert.Equal(t, osc52("a b\t2 KiB", "xterm", false), output.String())
+}
+
+func TestOSC52Passthrough(t *testing.T) {
+	const plain = "\x1b]52;c;aGk=\x07"
+
+	assert.Equal(t, plain, osc52("hi", "xterm-256color", false))
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\", osc52("hi", "screen", true))
+	assert.Equal(t, "\x1bP"+plain+"\x1b\\", osc52("hi", "screen.xterm-256color", false))
+
+	// Screen passes through long sequences in chunks
+	long := osc52(strings.Repeat("x", 200), "screen", false)
+
+	assert.Equal(t, 4, strings.Count(long, "\x1bP"))
+	assert.Equal(t, osc52(strings.Repeat("x", 200), "xterm", false), strings.NewReplacer("\x1bP", "", "\x1b\\", "").Replace(long))
+}
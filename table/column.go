@@ -2,6 +2,7 @@ package table
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
 )
 
 // Column is a column in the table.
@@ -12,10 +13,33 @@ type Column struct {
 
 	flexFactor int
 
+	// If true, the width is measured from the title and data
+	autoWidth bool
+
+	// The percentage of the table's width to fill, where 0 is unused
+	percentWidth int
+
+	// Limits on the width, where 0 is no limit
+	minWidth int
+	maxWidth int
+
 	filterable bool
 	style      lipgloss.Style
 
 	fmtString string
+	formatter CellFormatter
+
+	editable      bool
+	editValidator CellEditValidator
+	editParser    CellEditParser
+	newCellEditor func(column Column) CellEditor
+
+	aggregate        AggregateFunc
+	summaryFormatter CellFormatter
+
+	sortFunc    SortFunc
+	sortValid   SortValidFunc
+	missingSort MissingSort
 }
 
 // NewColumn creates a new fixed-width column with the given information.
@@ -45,6 +69,39 @@ func NewFlexColumn(key, title string, flexFactor int) Column {
 	}
 }
 
+// NewPercentColumn creates a new column that fills the given percentage of the
+// table's width, not counting borders, within any limits set with WithMinWidth
+// and WithMaxWidth.  Any flex columns share the width that's left.  You must
+// use WithTargetWidth if you have any percentage columns, so that the table
+// knows its width.
+func NewPercentColumn(key, title string, percent int) Column {
+	return Column{
+		key:   key,
+		title: title,
+		width: 1,
+
+		percentWidth: max(percent, 0),
+	}
+}
+
+// NewAutoColumn creates a new column that's wide enough to fit its title and
+// the displayed data in each row, measured again whenever the rows change.
+// Use WithMinWidth and WithMaxWidth to limit the width, and
+// WithAutoColumnSampleSize to choose how many rows are measured in large
+// tables.
+// If the table has a target width set by WithTargetWidth and the columns don't
+// fit, auto columns are narrowed as evenly as possible, down to their minimum
+// widths, before any flex columns are given space.
+func NewAutoColumn(key, title string) Column {
+	return Column{
+		key:   key,
+		title: title,
+		width: ansi.PrintableRuneWidth(title),
+
+		autoWidth: true,
+	}
+}
+
 // WithStyle applies a style to the column as a whole.
 func (c Column) WithStyle(style lipgloss.Style) Column {
 	c.style = style.Copy().Width(c.width)
@@ -73,6 +130,134 @@ func (c Column) WithFormatString(fmtString string) Column {
 	return c
 }
 
+// WithCellFormatter sets a function that turns the raw data of each cell into
+// the text that's displayed, such as FormatBytes.  This takes priority over
+// WithFormatString.  Sorting and numeric filtering still use the raw data, so
+// a column of byte counts sorts by size rather than by the displayed text.
+// The missing data indicator is not passed through the formatter.
+func (c Column) WithCellFormatter(formatter CellFormatter) Column {
+	c.formatter = formatter
+
+	return c
+}
+
+// WithEditable sets whether the user can edit cells in this column.  Editing
+// requires the cell cursor to be enabled with WithCellCursor.
+func (c Column) WithEditable(editable bool) Column {
+	c.editable = editable
+
+	return c
+}
+
+// WithEditValidator sets a function to check the edited text before it's
+// committed.  If the validator returns an error, the edit is not committed and
+// the error is shown in the footer.
+func (c Column) WithEditValidator(validator CellEditValidator) Column {
+	c.editValidator = validator
+
+	return c
+}
+
+// WithEditParser sets a function to convert the edited text into the value
+// stored in the row.  If not set, the text is stored as a string.  If the
+// parser returns an error, the edit is not committed and the error is shown
+// in the footer.
+func (c Column) WithEditParser(parser CellEditParser) Column {
+	c.editParser = parser
+
+	return c
+}
+
+// WithCellEditor sets a function that creates the editor used when editing a
+// cell in this column.  If not set, a text input is used.
+func (c Column) WithCellEditor(newEditor func(column Column) CellEditor) Column {
+	c.newCellEditor = newEditor
+
+	return c
+}
+
+// WithAggregate sets the function used to summarize the column in the summary
+// row, such as AggregateSum.  The summary row is shown with WithSummaryRow.
+// The result is displayed with the column's cell formatter or format string,
+// so a sum of byte counts is shown the same way as the cells.  Use
+// WithSummaryFormatter if the result isn't in the same units as the cells,
+// such as with AggregateCount.
+func (c Column) WithAggregate(aggregate AggregateFunc) Column {
+	c.aggregate = aggregate
+
+	return c
+}
+
+// WithSummaryFormatter sets a function that turns the result of the column's
+// aggregate into the text shown in the summary row, instead of the column's
+// cell formatter or format string.  Set to nil to go back to using those.
+func (c Column) WithSummaryFormatter(formatter CellFormatter) Column {
+	c.summaryFormatter = formatter
+
+	return c
+}
+
+// WithSortFunc sets a function to compare the column's values when sorting,
+// such as SortNatural, instead of comparing numbers by value and anything
+// else as strings.
+func (c Column) WithSortFunc(sortFunc SortFunc) Column {
+	c.sortFunc = sortFunc
+
+	return c
+}
+
+// WithSortValid sets a function that returns true if a value can be sorted
+// by the column's SortFunc, such as IsSemver for SortSemver.  Rows with values
+// that aren't valid are placed after the others in both sort directions, and
+// are compared with each other as usual.
+func (c Column) WithSortValid(valid SortValidFunc) Column {
+	c.sortValid = valid
+
+	return c
+}
+
+// WithMissingSort sets where rows that are missing the column's data, or have
+// nil data, are placed when sorting by the column.  With MissingSortFirst or
+// MissingSortLast, the placement is the same in both directions.
+func (c Column) WithMissingSort(missingSort MissingSort) Column {
+	c.missingSort = missingSort
+
+	return c
+}
+
+// WithMinWidth sets the narrowest the column can be resized or automatically
+// sized to, including the width given to flex and percentage columns.  Columns
+// are always at least 1 wide.
+func (c Column) WithMinWidth(width int) Column {
+	c.minWidth = width
+
+	return c
+}
+
+// WithMaxWidth sets the widest the column can be resized or automatically
+// sized to, including the width given to flex and percentage columns.  Any
+// width that a flex column can't use is shared by the other flex columns.  Set
+// to 0 for no limit, which is the default.
+func (c Column) WithMaxWidth(width int) Column {
+	c.maxWidth = width
+
+	return c
+}
+
+// clampWidth returns the width limited by the column's minimum and maximum.
+func (c *Column) clampWidth(width int) int {
+	if c.maxWidth > 0 {
+		width = min(width, c.maxWidth)
+	}
+
+	return max(width, c.minimumWidth())
+}
+
+// minimumWidth returns the narrowest the column can be.
+func (c *Column) minimumWidth() int {
+	return max(c.minWidth, 1)
+}
+
 func (c *Column) isFlex() bool {
 	return c.flexFactor != 0
 }
@@ -97,6 +282,29 @@ func (c Column) FlexFactor() int {
 	return c.flexFactor
 }
 
+// MinWidth returns the minimum width of the column set with WithMinWidth.
+func (c Column) MinWidth() int {
+	return c.minWidth
+}
+
+// MaxWidth returns the maximum width of the column set with WithMaxWidth, or 0
+// if there's no limit.
+func (c Column) MaxWidth() int {
+	return c.maxWidth
+}
+
+// PercentWidth returns the percentage of the table's width that the column
+// fills, or 0 if it's not a percentage column.
+func (c Column) PercentWidth() int {
+	return c.percentWidth
+}
+
+// IsAutoWidth returns whether the column is sized to fit its data, as created
+// by NewAutoColumn.
+func (c Column) IsAutoWidth() bool {
+	return c.autoWidth
+}
+
 // IsFlex returns whether the column is a flex column.
 func (c Column) IsFlex() bool {
 	return c.isFlex()
@@ -116,3 +324,14 @@ func (c Column) Style() lipgloss.Style {
 func (c Column) FmtString() string {
 	return c.fmtString
 }
+
+// CellFormatter returns the cell formatter of the column, or nil if none was
+// set with WithCellFormatter.
+func (c Column) CellFormatter() CellFormatter {
+	return c.formatter
+}
+
+// Editable returns whether the column's cells can be edited by the user.
+func (c Column) Editable() bool {
+	return c.editable
+}
@@ -0,0 +1,403 @@
+package table
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+const columnKeyChooser = "___column_chooser___"
+
+// WithColumnArranging sets whether the user can move, hide, and show columns
+// from the keyboard when focused.  The SortColumnNext and SortColumnPrev keys
+// choose a column, which is highlighted in the header, the ColumnMoveLeft and
+// ColumnMoveRight keys move it, and the ColumnHide key hides it.  The
+// ColumnChooser key opens a list of all the columns with checkboxes, where
+// hidden columns can be shown again and columns can be moved.  Use ColumnOrder
+// and HiddenColumns to get the result, such as to save it and restore it later
+// with WithColumnOrder and WithHiddenColumns.
+func (m Model) WithColumnArranging(enabled bool) Model {
+	m.columnArranging = enabled
+
+	if !enabled {
+		m.columnChooserOpen = false
+	}
+
+	return m
+}
+
+// WithColumnOrder sets the order of the columns by key, including hidden
+// columns.  Columns that aren't listed keep their order after the listed
+// columns, and keys that don't match a column are ignored.
+func (m Model) WithColumnOrder(columnKeys []string) Model {
+	columns := m.allColumns()
+	ordered := make([]Column, 0, len(columns))
+	placed := make(map[string]bool, len(columns))
+
+	for _, columnKey := range columnKeys {
+		for _, column := range columns {
+			if column.key == columnKey && !placed[columnKey] {
+				ordered = append(ordered, column)
+				placed[columnKey] = true
+			}
+		}
+	}
+
+	for _, column := range columns {
+		if !placed[column.key] {
+			ordered = append(ordered, column)
+		}
+	}
+
+	m.arrangeColumns(ordered, m.hiddenColumnKeys())
+
+	return m
+}
+
+// WithHiddenColumns sets which columns are hidden by key, showing any other
+// hidden columns.  Hidden columns aren't rendered, filtered, or exported, but
+// keep their place in the column order so they can be shown again.
+func (m Model) WithHiddenColumns(columnKeys []string) Model {
+	hidden := make(map[string]bool, len(columnKeys))
+
+	for _, columnKey := range columnKeys {
+		hidden[columnKey] = true
+	}
+
+	m.arrangeColumns(m.allColumns(), hidden)
+
+	return m
+}
+
+// ColumnOrder returns the keys of all the columns in order, including hidden
+// columns.
+func (m Model) ColumnOrder() []string {
+	columns := m.allColumns()
+	columnKeys := make([]string, len(columns))
+
+	for i, column := range columns {
+		columnKeys[i] = column.key
+	}
+
+	return columnKeys
+}
+
+// HiddenColumns returns the keys of the hidden columns in order.
+func (m Model) HiddenColumns() []string {
+	columnKeys := []string{}
+
+	for _, columnKey := range m.columnOrder {
+		if _, hidden := m.hiddenColumns[columnKey]; hidden {
+			columnKeys = append(columnKeys, columnKey)
+		}
+	}
+
+	return columnKeys
+}
+
+// IsColumnChooserOpen returns true if the column chooser is open, in which
+// case it takes all key presses until it's closed.
+func (m Model) IsColumnChooserOpen() bool {
+	return m.columnChooserOpen
+}
+
+// allColumns returns all the columns in order other than the select column,
+// including hidden columns.
+func (m *Model) allColumns() []Column {
+	columns := make([]Column, 0, len(m.columns)+len(m.hiddenColumns))
+	shown := make(map[string]Column, len(m.columns))
+
+	for _, column := range m.columns {
+		if column.key == columnKeySelect {
+			continue
+		}
+
+		if m.columnOrder == nil {
+			columns = append(columns, column)
+		} else {
+			shown[column.key] = column
+		}
+	}
+
+	for _, columnKey := range m.columnOrder {
+		if column, exists := shown[columnKey]; exists {
+			columns = append(columns, column)
+		} else if column, exists := m.hiddenColumns[columnKey]; exists {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// hiddenColumnKeys returns the set of hidden column keys.
+func (m *Model) hiddenColumnKeys() map[string]bool {
+	hidden := make(map[string]bool, len(m.hiddenColumns))
+
+	for columnKey := range m.hiddenColumns {
+		hidden[columnKey] = true
+	}
+
+	return hidden
+}
+
+// arrangeColumns shows the given columns in order, other than the hidden ones,
+// keeping the select column first.
+func (m *Model) arrangeColumns(columns []Column, hidden map[string]bool) {
+	cursorColumn, _ := m.cursorColumn()
+
+	shown := make([]Column, 0, len(columns)+1)
+
+	if len(m.columns) > 0 && m.columns[0].key == columnKeySelect {
+		shown = append(shown, m.columns[0])
+	}
+
+	m.columnOrder = make([]string, 0, len(columns))
+	m.hiddenColumns = make(map[string]Column)
+
+	for _, column := range columns {
+		m.columnOrder = append(m.columnOrder, column.key)
+
+		if hidden[column.key] {
+			m.hiddenColumns[column.key] = column
+		} else {
+			shown = append(shown, column)
+		}
+	}
+
+	m.columns = shown
+
+	// Keep the cursor on the same column if it's still shown
+	for i, column := range m.columns {
+		if column.key == cursorColumn.key {
+			m.columnCursorIndex = i
+		}
+	}
+
+	// Column aggregates for the summary row may have changed
+	if m.summaryRow {
+		m.visibleRowCacheUpdated = false
+	}
+
+	// Filtering only checks shown columns
+	if m.filtered {
+		m.invalidateQuery()
+	}
+
+	m.recalculateWidth()
+
+	m.horizontalScrollOffsetCol = min(m.horizontalScrollOffsetCol, m.maxHorizontalColumnIndex)
+
+	if m.minimumHeight > 0 {
+		m.recalculateHeight()
+	}
+}
+
+// moveColumn returns the columns with the column at the given index moved one
+// place in the direction of delta, past any hidden columns unless
+// includeHidden is set.  Returns false if it can't move any further.
+func moveColumn(columns []Column, hidden map[string]bool, index, delta int, includeHidden bool) ([]Column, bool) {
+	target := index + delta
+
+	for !includeHidden && target >= 0 && target < len(columns) && hidden[columns[target].key] {
+		target += delta
+	}
+
+	if target < 0 || target >= len(columns) {
+		return columns, false
+	}
+
+	moved := make([]Column, 0, len(columns))
+	moved = append(moved, columns[:index]...)
+	moved = append(moved, columns[index+1:]...)
+	moved = append(moved[:target], append([]Column{columns[index]}, moved[target:]...)...)
+
+	return moved, true
+}
+
+// columnsChanged generates an event for the current column arrangement.
+func (m *Model) columnsChanged() {
+	m.appendUserEvent(UserEventColumnsChanged{
+		ColumnOrder:      m.ColumnOrder(),
+		HiddenColumnKeys: m.HiddenColumns(),
+	})
+}
+
+func (m *Model) handleColumnArrangeKeypress(msg tea.KeyMsg) {
+	if key.Matches(msg, m.keyMap.ColumnChooser) {
+		m.columnChooserOpen = true
+		m.columnChooserIndex = 0
+
+		return
+	}
+
+	column, ok := m.cursorColumn()
+
+	if !ok || column.key == columnKeySelect {
+		return
+	}
+
+	columns := m.allColumns()
+	hidden := m.hiddenColumnKeys()
+	index := 0
+
+	for i := range columns {
+		if columns[i].key == column.key {
+			index = i
+		}
+	}
+
+	switch {
+	case key.Matches(msg, m.keyMap.ColumnMoveLeft), key.Matches(msg, m.keyMap.ColumnMoveRight):
+		delta := 1
+
+		if key.Matches(msg, m.keyMap.ColumnMoveLeft) {
+			delta = -1
+		}
+
+		if columns, ok = moveColumn(columns, hidden, index, delta, false); !ok {
+			return
+		}
+
+		m.arrangeColumns(columns, hidden)
+		m.scrollColumnIntoView(m.columnCursorIndex)
+
+	case key.Matches(msg, m.keyMap.ColumnHide):
+		// Always leave at least one column to show
+		if len(columns)-len(hidden) <= 1 {
+			return
+		}
+
+		hidden[column.key] = true
+
+		m.arrangeColumns(columns, hidden)
+
+	default:
+		return
+	}
+
+	m.columnsChanged()
+}
+
+func (m *Model) handleColumnChooserKeypress(msg tea.KeyMsg) {
+	columns := m.allColumns()
+	hidden := m.hiddenColumnKeys()
+
+	if len(columns) == 0 || key.Matches(msg, m.keyMap.ColumnChooserClose) {
+		m.columnChooserOpen = false
+
+		return
+	}
+
+	index := min(m.columnChooserIndex, len(columns)-1)
+	chosen := columns[index]
+
+	switch {
+	case key.Matches(msg, m.keyMap.RowUp):
+		m.columnChooserIndex = (index - 1 + len(columns)) % len(columns)
+
+		return
+
+	case key.Matches(msg, m.keyMap.RowDown):
+		m.columnChooserIndex = (index + 1) % len(columns)
+
+		return
+
+	case key.Matches(msg, m.keyMap.ColumnChooserToggle):
+		if hidden[chosen.key] {
+			delete(hidden, chosen.key)
+		} else if len(columns)-len(hidden) > 1 {
+			hidden[chosen.key] = true
+		} else {
+			return
+		}
+
+	case key.Matches(msg, m.keyMap.ColumnMoveLeft), key.Matches(msg, m.keyMap.ColumnMoveRight):
+		delta := 1
+
+		if key.Matches(msg, m.keyMap.ColumnMoveLeft) {
+			delta = -1
+		}
+
+		var ok bool
+
+		if columns, ok = moveColumn(columns, hidden, index, delta, true); !ok {
+			return
+		}
+
+		m.columnChooserIndex = index + delta
+
+	default:
+		return
+	}
+
+	m.arrangeColumns(columns, hidden)
+	m.columnsChanged()
+}
+
+// renderColumnChooser renders the column chooser in the middle of the space
+// taken by the table.  If there are too many columns to fit, it shows the
+// columns around the chosen one.
+func (m Model) renderColumnChooser(table string) string {
+	// The borders take two lines and two columns, and the header two lines
+	const (
+		chooserBorderSize   = 2
+		chooserHeaderHeight = 2
+	)
+
+	tableWidth := lipgloss.Width(table)
+	tableHeight := lipgloss.Height(table)
+	columns := m.allColumns()
+	hidden := m.hiddenColumnKeys()
+	title := "Columns"
+	width := ansi.PrintableRuneWidth(title)
+
+	// Leave out the title if it would leave no room for any columns
+	showHeader := tableHeight-chooserBorderSize-chooserHeaderHeight > 0
+	maxRows := tableHeight - chooserBorderSize
+
+	if showHeader {
+		maxRows -= chooserHeaderHeight
+	}
+
+	index := min(m.columnChooserIndex, len(columns)-1)
+	start := 0
+	end := len(columns)
+
+	if maxRows = max(maxRows, 1); len(columns) > maxRows {
+		start = min(max(index-maxRows/2, 0), len(columns)-maxRows)
+		end = start + maxRows
+	}
+
+	rows := make([]Row, 0, end-start)
+
+	for _, column := range columns[start:end] {
+		checkbox := m.selectedText
+
+		if hidden[column.key] {
+			checkbox = m.unselectedText
+		}
+
+		text := checkbox + " " + column.title
+		width = max(width, ansi.PrintableRuneWidth(text))
+		rows = append(rows, NewRow(RowData{columnKeyChooser: text}))
+	}
+
+	// Keep within the table's borders, cutting off long titles
+	width = max(min(width, tableWidth-chooserBorderSize), 1)
+
+	chooser := New([]Column{NewColumn(columnKeyChooser, title, width)}).
+		WithRows(rows).
+		WithHighlightedRow(index - start).
+		WithBaseStyle(m.baseStyle.Copy().Align(lipgloss.Left)).
+		HighlightStyle(m.highlightStyle).
+		HeaderStyle(m.headerStyle).
+		WithHeaderVisibility(showHeader).
+		WithFooterVisibility(false).
+		Focused(true)
+
+	chooser.border = m.border
+
+	return lipgloss.Place(tableWidth, tableHeight, lipgloss.Center, lipgloss.Center, chooser.View())
+}
@@ -0,0 +1,223 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	columnMoveLeftKey  = tea.KeyMsg{Type: tea.KeyLeft, Alt: true}
+	columnMoveRightKey = tea.KeyMsg{Type: tea.KeyRight, Alt: true}
+	columnHideKey      = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}}
+	columnChooserKey   = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}}
+)
+
+func genColumnArrangeModel() Model {
+	return New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+		NewColumn("c", "C", 3),
+	}).WithRows([]Row{
+		NewRow(RowData{"a": "a1", "b": "b1", "c": "c1"}),
+	}).WithColumnArranging(true).Focused(true).WithFooterVisibility(false)
+}
+
+func TestColumnArrangeMoveAndHide(t *testing.T) {
+	model := genColumnArrangeModel()
+
+	model, _ = model.Update(columnMoveLeftKey)
+
+	assert.Empty(t, model.GetLastUpdateUserEvents(), "Should not move past the first column")
+
+	model, _ = model.Update(columnMoveRightKey)
+
+	assert.Equal(t, []UserEvent{UserEventColumnsChanged{
+		ColumnOrder:      []string{"b", "a", "c"},
+		HiddenColumnKeys: []string{},
+	}}, model.GetLastUpdateUserEvents())
+
+	_, columnKey := model.HighlightedCell()
+
+	assert.Equal(t, "a", columnKey, "Cursor should follow the moved column")
+
+	model, _ = model.Update(columnHideKey)
+
+	assert.Equal(t, []string{"b", "a", "c"}, model.ColumnOrder())
+	assert.Equal(t, []string{"a"}, model.HiddenColumns())
+
+	const expectedTable = `┏━━━┳━━━┓
+┃  B┃  C┃
+┣━━━╋━━━┫
+┃ b1┃ c1┃
+┗━━━┻━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	// Moves past hidden columns
+	model, _ = model.Update(columnMoveLeftKey)
+
+	assert.Equal(t, []string{"c", "b", "a"}, model.ColumnOrder())
+
+	// Always leaves one column
+	model, _ = model.Update(columnHideKey)
+	model, _ = model.Update(columnHideKey)
+
+	assert.Equal(t, []string{"c", "a"}, model.HiddenColumns())
+	assert.Len(t, model.columns, 1)
+}
+
+func TestColumnArrangeDisabled(t *testing.T) {
+	model := genColumnArrangeModel().WithColumnArranging(false)
+
+	model, _ = model.Update(columnHideKey)
+	model, _ = model.Update(columnChooserKey)
+
+	assert.Empty(t, model.HiddenColumns())
+	assert.False(t, model.IsColumnChooserOpen())
+}
+
+func TestColumnChooser(t *testing.T) {
+	model := genColumnArrangeModel().WithHiddenColumns([]string{"b"})
+
+	model, _ = model.Update(columnChooserKey)
+
+	assert.True(t, model.IsColumnChooserOpen())
+
+	view := model.View()
+
+	// The table only has room for one column in the chooser
+	assert.Contains(t, view, "Columns")
+	assert.Contains(t, view, "[x] A")
+	assert.NotContains(t, view, "B")
+
+	// Show B again
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Contains(t, model.View(), "[ ] B")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	assert.Empty(t, model.HiddenColumns())
+	assert.Equal(t, []UserEvent{UserEventColumnsChanged{
+		ColumnOrder:      []string{"a", "b", "c"},
+		HiddenColumnKeys: []string{},
+	}}, model.GetLastUpdateUserEvents())
+
+	// Move B down, and the chooser follows it
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+
+	assert.Equal(t, []string{"a", "c", "b"}, model.ColumnOrder())
+	assert.Equal(t, 2, model.columnChooserIndex)
+
+	// Other keys don't reach the table while the chooser is open
+	model, _ = model.Update(columnHideKey)
+
+	assert.Empty(t, model.HiddenColumns())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.False(t, model.IsColumnChooserOpen())
+
+	const expectedTable = `┏━━━┳━━━┳━━━┓
+┃  A┃  C┃  B┃
+┣━━━╋━━━╋━━━┫
+┃ a1┃ c1┃ b1┃
+┗━━━┻━━━┻━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestColumnChooserOverlay(t *testing.T) {
+	model := genColumnArrangeModel().WithMinimumHeight(10)
+
+	model, _ = model.Update(columnChooserKey)
+
+	lines := strings.Split(model.View(), "\n")
+
+	assert.Len(t, lines, 10, "Should take the same space as the table")
+	assert.Contains(t, lines[1], "┏")
+}
+
+func TestColumnChooserFitsInTable(t *testing.T) {
+	columns := []Column{}
+
+	for _, title := range "ABCDEFGHIJ" {
+		columns = append(columns, NewColumn(string(title), string(title)+" with a title that's wider than the table", 2))
+	}
+
+	model := New(columns).
+		WithRows([]Row{NewRow(RowData{})}).
+		WithColumnArranging(true).
+		WithMinimumHeight(7).
+		Focused(true)
+
+	model, _ = model.Update(columnChooserKey)
+
+	for i := 0; i < 5; i++ {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	view := model.View()
+	table := model.WithColumnArranging(false).View()
+
+	assert.Equal(t, lipgloss.Height(table), lipgloss.Height(view))
+	assert.Equal(t, lipgloss.Width(table), lipgloss.Width(view))
+
+	// Three columns fit, centered on the chosen one
+	assert.NotContains(t, view, "[x] D")
+	assert.Contains(t, view, "[x] E")
+	assert.Contains(t, view, "[x] F")
+	assert.Contains(t, view, "[x] G")
+	assert.NotContains(t, view, "[x] H")
+
+	// Without room for the title, it's left out
+	model = model.WithMinimumHeight(0).WithHeaderVisibility(false)
+	table = model.WithColumnArranging(false).View()
+
+	assert.Equal(t, lipgloss.Height(table), lipgloss.Height(model.View()))
+	assert.NotContains(t, model.View(), "Columns")
+}
+
+func TestColumnOrderAndHiddenColumns(t *testing.T) {
+	model := genColumnArrangeModel().
+		SelectableRows(true).
+		WithColumnOrder([]string{"c", "missing", "a"}).
+		WithHiddenColumns([]string{"a"})
+
+	assert.Equal(t, []string{"c", "a", "b"}, model.ColumnOrder())
+	assert.Equal(t, []string{"a"}, model.HiddenColumns())
+	assert.Equal(t, []string{columnKeySelect, "c", "b"}, []string{model.columns[0].key, model.columns[1].key, model.columns[2].key})
+
+	// Hidden columns aren't exported
+	buffer := bytes.Buffer{}
+
+	assert.NoError(t, model.Export(&buffer, ExportOptions{Format: ExportFormatCSV}))
+	assert.Equal(t, "C,B\nc1,b1\n", buffer.String())
+
+	// Setting columns replaces the arrangement
+	model = model.WithColumns([]Column{NewColumn("a", "A", 3), NewColumn("b", "B", 3)})
+
+	assert.Equal(t, []string{"a", "b"}, model.ColumnOrder())
+	assert.Empty(t, model.HiddenColumns())
+}
+
+func TestColumnHideFiltersShownColumnsOnly(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3).WithFiltered(true),
+		NewColumn("b", "B", 3).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"a": "x", "b": "y"}),
+		NewRow(RowData{"a": "y", "b": "z"}),
+	}).Filtered(true).WithFilterInputValue("y")
+
+	assert.Len(t, model.GetVisibleRows(), 2)
+
+	model = model.WithHiddenColumns([]string{"b"})
+
+	assert.Len(t, model.GetVisibleRows(), 1)
+}
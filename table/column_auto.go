@@ -0,0 +1,203 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/muesli/reflow/ansi"
+)
+
+// defaultAutoColumnSampleSize is how many rows are measured for auto columns
+// unless set otherwise, so that large tables are quick to measure.
+const defaultAutoColumnSampleSize = 1000
+
+// autoColumnWidthKey identifies a measured auto column width, since the tree
+// column's width also includes the tree indentation.
+type autoColumnWidthKey struct {
+	columnKey    string
+	isTreeColumn bool
+}
+
+// WithAutoColumnSampleSize sets how many rows are measured to find the widths
+// of columns created with NewAutoColumn, spread evenly through the rows.  The
+// children of measured rows are also measured.  The default is 1000 rows, so
+// that very large tables are quick to measure.  Set to 0 to measure every row.
+func (m Model) WithAutoColumnSampleSize(size int) Model {
+	m.autoColumnSampleSize = size
+	m.autoColumnWidths = nil
+
+	m.recalculateWidth()
+
+	return m
+}
+
+// hasAutoColumns returns true if any column is sized to fit its data.
+func (m *Model) hasAutoColumns() bool {
+	for _, column := range m.columns {
+		if column.autoWidth {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remeasureAutoColumns measures any columns that are sized to fit their data
+// again and recalculates the width of the table, such as after the rows or the
+// missing data indicator have changed.
+func (m *Model) remeasureAutoColumns() {
+	m.autoColumnWidths = nil
+
+	if m.hasAutoColumns() {
+		m.recalculateWidth()
+	}
+}
+
+// measureAutoColumns sets the width of each auto column to fit its title and
+// data, within its minimum and maximum widths.  Widths that were already
+// measured are reused.
+func (m *Model) measureAutoColumns() {
+	if !m.hasAutoColumns() {
+		return
+	}
+
+	var rows []Row
+
+	treeColumnKey := m.treeColumnKey()
+	widths := m.autoColumnWidths
+	copied := false
+
+	// Copy so that earlier copies of the model keep their widths
+	m.columns = append([]Column{}, m.columns...)
+
+	for i, column := range m.columns {
+		if !column.autoWidth {
+			continue
+		}
+
+		key := autoColumnWidthKey{
+			columnKey:    column.key,
+			isTreeColumn: m.treeRows && column.key == treeColumnKey,
+		}
+
+		width, measured := widths[key]
+
+		if !measured {
+			if rows == nil {
+				rows = m.autoColumnSampleRows()
+			}
+
+			width = m.measureRows(column, rows, 0, key.isTreeColumn)
+
+			// Copies of the model share the widths, so replace them
+			if !copied {
+				widths = make(map[autoColumnWidthKey]int, len(m.autoColumnWidths)+1)
+
+				for existingKey, existingWidth := range m.autoColumnWidths {
+					widths[existingKey] = existingWidth
+				}
+
+				copied = true
+			}
+
+			widths[key] = width
+		}
+
+		m.columns[i].width = column.clampWidth(max(width, ansi.PrintableRuneWidth(column.title)))
+	}
+
+	m.autoColumnWidths = widths
+}
+
+// autoColumnSampleRows returns the rows to measure for auto columns.
+func (m *Model) autoColumnSampleRows() []Row {
+	if m.autoColumnSampleSize <= 0 || len(m.rows) <= m.autoColumnSampleSize {
+		return m.rows
+	}
+
+	sample := make([]Row, m.autoColumnSampleSize)
+
+	for i := range sample {
+		sample[i] = m.rows[i*len(m.rows)/len(sample)]
+	}
+
+	return sample
+}
+
+// measureRows returns the widest displayed data for the column in the rows and
+// all their children, including the tree indentation if it's the tree column.
+func (m *Model) measureRows(column Column, rows []Row, depth int, isTreeColumn bool) int {
+	width := 0
+
+	for _, row := range rows {
+		cellWidth := ansi.PrintableRuneWidth(m.cellText(row, column))
+
+		if isTreeColumn {
+			row.depth = depth
+			cellWidth += ansi.PrintableRuneWidth(treePrefix(row))
+		}
+
+		width = max(width, cellWidth)
+		width = max(width, m.measureRows(column, row.children, depth+1, isTreeColumn))
+	}
+
+	return width
+}
+
+// cellText returns the text shown for the column in the row before it's
+// limited to the column's width.
+func (m *Model) cellText(row Row, column Column) string {
+	data, exists := row.Data[column.key]
+
+	if !exists {
+		if m.missingDataIndicator == nil {
+			return ""
+		}
+
+		if styled, isStyled := m.missingDataIndicator.(StyledCell); isStyled {
+			return fmt.Sprintf("%v", styled.Data)
+		}
+
+		return fmt.Sprintf("%v", m.missingDataIndicator)
+	}
+
+	if styled, isStyled := data.(StyledCell); isStyled {
+		data = styled.Data
+	}
+
+	return column.formatData(data)
+}
+
+// shrinkAutoColumns narrows auto columns as evenly as possible, down to their
+// minimum widths, so that the columns fit in the total width while leaving
+// room for each flex column's minimum width.
+func shrinkAutoColumns(cols []Column, totalWidth int) {
+	excess := len(cols) + 1 - totalWidth
+
+	for _, col := range cols {
+		if col.isFlex() {
+			excess += col.minimumWidth()
+		} else {
+			excess += col.width
+		}
+	}
+
+	for ; excess > 0; excess-- {
+		widest := -1
+
+		for i := range cols {
+			if !cols[i].autoWidth || cols[i].width <= cols[i].minimumWidth() {
+				continue
+			}
+
+			if widest == -1 || cols[i].width > cols[widest].width {
+				widest = i
+			}
+		}
+
+		if widest == -1 {
+			return
+		}
+
+		cols[widest].width--
+	}
+}
@@ -0,0 +1,168 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoColumnFitsData(t *testing.T) {
+	model := New([]Column{
+		NewAutoColumn("name", "Name"),
+		NewAutoColumn("size", "Size").WithCellFormatter(FormatBytes),
+		NewAutoColumn("note", "Note").WithMaxWidth(6),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Alexander", "size": 2048, "note": "a long note"}),
+		NewRow(RowData{"name": "日本", "size": NewStyledCell(1, defaultHighlightStyle)}),
+	}).WithMissingDataIndicator("-").WithFooterVisibility(false)
+
+	assert.Equal(t, map[string]int{"name": 9, "size": 5, "note": 6}, model.ColumnWidths())
+	assert.True(t, model.columns[0].IsAutoWidth())
+
+	const expectedTable = `┏━━━━━━━━━┳━━━━━┳━━━━━━┓
+┃     Name┃ Size┃  Note┃
+┣━━━━━━━━━╋━━━━━╋━━━━━━┫
+┃Alexander┃2 KiB┃a lon…┃
+┃     日本┃  1 B┃     -┃
+┗━━━━━━━━━┻━━━━━┻━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestAutoColumnRemeasuresWhenRowsChange(t *testing.T) {
+	model := New([]Column{
+		NewAutoColumn("name", "Name").WithMinWidth(3),
+	})
+
+	assert.Equal(t, 4, model.ColumnWidths()["name"], "Should fit the title with no rows")
+
+	model = model.WithRows([]Row{NewRow(RowData{"name": "abcdef"})})
+
+	assert.Equal(t, 6, model.ColumnWidths()["name"])
+
+	model = model.WithRows(append(model.GetVisibleRows(), NewRow(RowData{"name": "abcdefgh"})))
+
+	assert.Equal(t, 8, model.ColumnWidths()["name"])
+	assert.Equal(t, 10, model.totalWidth)
+}
+
+func TestAutoColumnSampleSize(t *testing.T) {
+	rows := []Row{}
+
+	for i := 0; i < 10; i++ {
+		name := "a"
+
+		if i%2 == 1 {
+			name = "abcdefgh"
+		}
+
+		rows = append(rows, NewRow(RowData{"name": name}))
+	}
+
+	model := New([]Column{NewAutoColumn("name", "N")}).WithRows(rows)
+
+	assert.Equal(t, 8, model.ColumnWidths()["name"])
+
+	// Only the even rows are measured
+	model = model.WithAutoColumnSampleSize(5)
+
+	assert.Equal(t, 1, model.ColumnWidths()["name"])
+}
+
+func TestAutoColumnTreeIndentation(t *testing.T) {
+	model := New([]Column{NewAutoColumn("name", "Name")}).WithRows([]Row{
+		NewRow(RowData{"name": "root"}).WithChildren(
+			NewRow(RowData{"name": "child"}),
+		),
+	})
+
+	// The child is indented once, followed by the glyph and a space
+	assert.Equal(t, len(treeIndent)+2+5, model.ColumnWidths()["name"])
+}
+
+func TestAutoColumnShrinksToTargetWidth(t *testing.T) {
+	model := New([]Column{
+		NewAutoColumn("a", "A").WithMinWidth(4),
+		NewAutoColumn("b", "B"),
+		NewFlexColumn("c", "C", 1),
+	}).WithRows([]Row{
+		NewRow(RowData{"a": "aaaaaaaa", "b": "bbbbbbbbbbbb"}),
+	}).WithTargetWidth(20)
+
+	// 20 minus 4 borders leaves 16, with 1 kept for the flex column
+	assert.Equal(t, map[string]int{"a": 7, "b": 8, "c": 1}, model.ColumnWidths())
+
+	model = model.WithTargetWidth(40)
+
+	assert.Equal(t, map[string]int{"a": 8, "b": 12, "c": 16}, model.ColumnWidths())
+
+	model = model.WithTargetWidth(10)
+
+	assert.Equal(t, 4, model.ColumnWidths()["a"], "Should stop at the minimum width")
+	assert.Equal(t, 1, model.ColumnWidths()["b"])
+}
+
+func TestAutoColumnResizedBecomesFixed(t *testing.T) {
+	model := New([]Column{NewAutoColumn("a", "A")}).
+		WithRows([]Row{NewRow(RowData{"a": "abc"})}).
+		ResizeColumn("a", 2)
+
+	assert.False(t, model.columns[0].IsAutoWidth())
+
+	model = model.WithRows([]Row{NewRow(RowData{"a": "abcdefghij"})})
+
+	assert.Equal(t, 5, model.ColumnWidths()["a"])
+}
+
+func TestAutoColumnWidthsKeptUntilDataChanges(t *testing.T) {
+	formatted := 0
+	formatter := func(data any) string {
+		formatted++
+
+		return fmt.Sprint(data)
+	}
+
+	model := New([]Column{
+		NewAutoColumn("name", "N").WithCellFormatter(formatter),
+		NewFlexColumn("rest", "Rest", 1),
+	}).WithRows([]Row{NewRow(RowData{"name": "abc"}), NewRow(RowData{"name": "abcdef"})})
+
+	measured := formatted
+
+	model = model.WithTargetWidth(40).WithTargetWidth(30)
+
+	assert.Equal(t, measured, formatted, "Should reuse the measured widths")
+	assert.Equal(t, 6, model.ColumnWidths()["name"])
+
+	model = model.WithMissingDataIndicator("-")
+
+	assert.Greater(t, formatted, measured, "Should measure again after the data changes")
+
+	model = model.WithRows([]Row{NewRow(RowData{"name": "ab"})})
+
+	assert.Equal(t, 2, model.ColumnWidths()["name"])
+}
+
+func TestAutoColumnDefaultSampleSize(t *testing.T) {
+	rows := make([]Row, defaultAutoColumnSampleSize*2)
+
+	for i := range rows {
+		name := "a"
+
+		// Only odd rows are wide, which the default sample skips
+		if i%2 == 1 {
+			name = "abcdefgh"
+		}
+
+		rows[i] = NewRow(RowData{"name": name})
+	}
+
+	model := New([]Column{NewAutoColumn("name", "N")}).WithRows(rows)
+
+	assert.Equal(t, 1, model.ColumnWidths()["name"])
+
+	model = model.WithAutoColumnSampleSize(0)
+
+	assert.Equal(t, 8, model.ColumnWidths()["name"])
+}
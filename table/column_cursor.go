@@ -0,0 +1,68 @@
+package table
+
+// firstCursorColumnIndex returns the first column index that can be chosen
+// with the column cursor, skipping the select column if it exists.
+func (m *Model) firstCursorColumnIndex() int {
+	if len(m.columns) > 0 && m.columns[0].key == columnKeySelect {
+		return 1
+	}
+
+	return 0
+}
+
+func (m *Model) clampColumnCursor() {
+	m.columnCursorIndex = min(m.columnCursorIndex, len(m.columns)-1)
+	m.columnCursorIndex = max(m.columnCursorIndex, m.firstCursorColumnIndex())
+}
+
+// cursorColumn returns the column chosen by the column cursor, if any.
+func (m *Model) cursorColumn() (Column, bool) {
+	if m.columnCursorIndex < 0 || m.columnCursorIndex >= len(m.columns) {
+		return Column{}, false
+	}
+
+	return m.columns[m.columnCursorIndex], true
+}
+
+// isColumnCursorShown returns true if the column cursor should be visible.
+func (m Model) isColumnCursorShown() bool {
+	return m.focused && (m.interactiveSorting || m.columnResizing || m.columnArranging)
+}
+
+func (m *Model) moveColumnCursor(delta int) {
+	m.columnCursorIndex += delta
+
+	m.clampColumnCursor()
+
+	m.scrollColumnIntoView(m.columnCursorIndex)
+}
+
+// isColumnVisible returns true if the column at the given index is rendered
+// with the current horizontal scrolling.
+func (m *Model) isColumnVisible(columnIndex int) bool {
+	for _, span := range m.columnLayout() {
+		if span.columnIndex == columnIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scrollColumnIntoView scrolls horizontally as little as possible so that the
+// column at the given index is rendered.
+func (m *Model) scrollColumnIntoView(columnIndex int) {
+	if columnIndex < m.horizontalScrollFreezeColumnsCount {
+		return
+	}
+
+	if columnIndex < m.horizontalScrollFreezeColumnsCount+m.horizontalScrollOffsetCol {
+		m.horizontalScrollOffsetCol = columnIndex - m.horizontalScrollFreezeColumnsCount
+
+		return
+	}
+
+	for !m.isColumnVisible(columnIndex) && m.horizontalScrollOffsetCol < m.maxHorizontalColumnIndex {
+		m.horizontalScrollOffsetCol++
+	}
+}
@@ -0,0 +1,116 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genCellCursorModel() Model {
+	return New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+		NewColumn("c", "C", 3),
+		NewColumn("d", "D", 3),
+	}).WithRows([]Row{
+		NewRow(RowData{"a": "a1", "b": "b1", "c": "c1", "d": "d1"}),
+		NewRow(RowData{"a": "a2", "b": "b2", "c": "c2", "d": "d2"}),
+	}).Focused(true).WithCellCursor(true)
+}
+
+func TestCellCursorMovesBetweenColumns(t *testing.T) {
+	model := genCellCursorModel()
+
+	cellRight := tea.KeyMsg{Type: tea.KeyTab}
+	cellLeft := tea.KeyMsg{Type: tea.KeyShiftTab}
+
+	row, columnKey := model.HighlightedCell()
+	assert.Equal(t, "a1", row.Data["a"])
+	assert.Equal(t, "a", columnKey)
+
+	model, _ = model.Update(cellRight)
+	_, columnKey = model.HighlightedCell()
+	assert.Equal(t, "b", columnKey)
+	assert.Equal(t, []UserEvent{
+		UserEventHighlightedColumnChanged{PreviousColumnKey: "a", ColumnKey: "b"},
+	}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	row, columnKey = model.HighlightedCell()
+	assert.Equal(t, "b2", row.Data[columnKey])
+
+	for i := 0; i < 5; i++ {
+		model, _ = model.Update(cellRight)
+	}
+
+	_, columnKey = model.HighlightedCell()
+	assert.Equal(t, "d", columnKey, "Should stop at the last column")
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+
+	for i := 0; i < 5; i++ {
+		model, _ = model.Update(cellLeft)
+	}
+
+	_, columnKey = model.HighlightedCell()
+	assert.Equal(t, "a", columnKey, "Should stop at the first column")
+}
+
+func TestCellCursorDisabledDoesNotMove(t *testing.T) {
+	model := genCellCursorModel().WithCellCursor(false)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	_, columnKey := model.HighlightedCell()
+	assert.Equal(t, "a", columnKey)
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+}
+
+func TestCellCursorSkipsSelectColumn(t *testing.T) {
+	model := genCellCursorModel().SelectableRows(true)
+
+	_, columnKey := model.HighlightedCell()
+	assert.Equal(t, "a", columnKey)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+
+	_, columnKey = model.HighlightedCell()
+	assert.Equal(t, "a", columnKey)
+}
+
+func TestCellCursorScrollsIntoView(t *testing.T) {
+	model := genCellCursorModel().WithMaxTotalWidth(14).WithHorizontalFreezeColumnCount(1)
+
+	cellRight := tea.KeyMsg{Type: tea.KeyTab}
+	cellLeft := tea.KeyMsg{Type: tea.KeyShiftTab}
+
+	model, _ = model.Update(cellRight)
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset(), "Column B should already be visible")
+
+	model, _ = model.Update(cellRight)
+	assert.Equal(t, 1, model.GetHorizontalScrollColumnOffset())
+	assert.True(t, model.isColumnVisible(2))
+
+	model, _ = model.Update(cellRight)
+	assert.Equal(t, 2, model.GetHorizontalScrollColumnOffset())
+	assert.True(t, model.isColumnVisible(3))
+
+	model, _ = model.Update(cellLeft)
+	assert.Equal(t, 1, model.GetHorizontalScrollColumnOffset())
+
+	model, _ = model.Update(cellLeft)
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset())
+
+	// Frozen columns never need scrolling
+	model, _ = model.Update(cellLeft)
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset())
+}
+
+func TestCellCursorMouseClickHighlightsCell(t *testing.T) {
+	model := genCellCursorModel().WithMouseSupport(true)
+
+	model, _ = model.Update(tea.MouseMsg{X: 10, Y: 4, Type: tea.MouseLeft})
+
+	row, columnKey := model.HighlightedCell()
+	assert.Equal(t, "c2", row.Data[columnKey])
+}
@@ -0,0 +1,144 @@
+package table
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithColumnResizing sets whether the user can resize columns from the
+// keyboard when focused.  The SortColumnNext and SortColumnPrev keys choose a
+// column, which is highlighted in the header, and the ColumnWiden and
+// ColumnNarrow keys change its width by one.  A flex, percentage, or auto
+// column that's resized becomes a fixed width column at its current width,
+// and widths are kept within any limits set with Column.WithMinWidth and
+// Column.WithMaxWidth.  Use ColumnWidths to get the resulting widths.
+func (m Model) WithColumnResizing(enabled bool) Model {
+	m.columnResizing = enabled
+
+	return m
+}
+
+// ResizeColumn changes the width of the column with the given key by delta,
+// which may be negative.  This works the same as resizing from the keyboard,
+// but is always allowed.
+func (m Model) ResizeColumn(columnKey string, delta int) Model {
+	for i := range m.columns {
+		if m.columns[i].key == columnKey && columnKey != columnKeySelect {
+			m.resizeColumn(i, m.columns[i].width+delta)
+
+			break
+		}
+	}
+
+	return m
+}
+
+// WithColumnWidths sets the widths of the columns with the given keys, such as
+// widths that were saved from ColumnWidths, including hidden columns.  Columns
+// set this way become fixed width columns, and widths are kept within any
+// limits set on the column.  Keys that don't match a column are ignored.
+func (m Model) WithColumnWidths(widths map[string]int) Model {
+	m.columns = append([]Column{}, m.columns...)
+
+	for i := range m.columns {
+		if width, exists := widths[m.columns[i].key]; exists && m.columns[i].key != columnKeySelect {
+			m.columns[i] = m.columns[i].withFixedWidth(width)
+		}
+	}
+
+	// Copy so that earlier copies of the model keep their hidden columns
+	hiddenColumns := make(map[string]Column, len(m.hiddenColumns))
+
+	for columnKey, column := range m.hiddenColumns {
+		if width, exists := widths[columnKey]; exists {
+			column = column.withFixedWidth(width)
+		}
+
+		hiddenColumns[columnKey] = column
+	}
+
+	m.hiddenColumns = hiddenColumns
+
+	m.recalculateWidth()
+
+	return m
+}
+
+// ColumnWidths returns the current width of each column by key, including
+// hidden columns, such as to save widths that the user has resized and restore
+// them later with WithColumnWidths.  The widths of flex columns are their
+// current widths, and hidden columns keep the width they had when hidden.
+func (m Model) ColumnWidths() map[string]int {
+	widths := make(map[string]int, len(m.columns)+len(m.hiddenColumns))
+
+	for _, column := range m.columns {
+		if column.key != columnKeySelect {
+			widths[column.key] = column.width
+		}
+	}
+
+	for columnKey, column := range m.hiddenColumns {
+		widths[columnKey] = column.width
+	}
+
+	return widths
+}
+
+// withFixedWidth returns the column as a fixed width column with the given
+// width, kept within its limits.
+func (c Column) withFixedWidth(width int) Column {
+	c.flexFactor = 0
+	c.autoWidth = false
+	c.percentWidth = 0
+	c.width = c.clampWidth(width)
+
+	return c
+}
+
+// resizeColumn sets the width of the column at the given index within its
+// limits, making it a fixed width column.  Returns true if the width changed.
+func (m *Model) resizeColumn(index, width int) bool {
+	column := m.columns[index]
+	width = column.clampWidth(width)
+
+	if width == column.width && !column.isFlex() && !column.autoWidth && column.percentWidth == 0 {
+		return false
+	}
+
+	// Copy so that earlier copies of the model keep their widths
+	m.columns = append([]Column{}, m.columns...)
+	m.columns[index] = m.columns[index].withFixedWidth(width)
+
+	m.recalculateWidth()
+
+	// Keep the resized column in view if the table overflows
+	m.horizontalScrollOffsetCol = min(m.horizontalScrollOffsetCol, m.maxHorizontalColumnIndex)
+	m.scrollColumnIntoView(index)
+
+	return true
+}
+
+func (m *Model) handleColumnResizeKeypress(msg tea.KeyMsg) {
+	delta := 0
+
+	if key.Matches(msg, m.keyMap.ColumnWiden) {
+		delta = 1
+	}
+
+	if key.Matches(msg, m.keyMap.ColumnNarrow) {
+		delta = -1
+	}
+
+	column, ok := m.cursorColumn()
+
+	if delta == 0 || !ok || column.key == columnKeySelect {
+		return
+	}
+
+	if m.resizeColumn(m.columnCursorIndex, column.width+delta) {
+		m.appendUserEvent(UserEventColumnResized{
+			ColumnKey: column.key,
+			Width:     m.columns[m.columnCursorIndex].width,
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	columnWidenKey  = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}}
+	columnNarrowKey = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'<'}}
+	columnNextKey   = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}}
+)
+
+func genColumnResizeModel() Model {
+	return New([]Column{
+		NewColumn("id", "ID", 3).WithMinWidth(2).WithMaxWidth(4),
+		NewColumn("name", "Name", 5),
+	}).WithRows([]Row{
+		NewRow(RowData{"id": 1, "name": "Alexander"}),
+	}).WithColumnResizing(true).Focused(true).WithFooterVisibility(false)
+}
+
+func TestColumnResizeFromKeyboard(t *testing.T) {
+	model := genColumnResizeModel()
+	original := model
+
+	model, _ = model.Update(columnWidenKey)
+
+	assert.Equal(t, []UserEvent{UserEventColumnResized{ColumnKey: "id", Width: 4}}, model.GetLastUpdateUserEvents())
+	assert.Equal(t, map[string]int{"id": 4, "name": 5}, model.ColumnWidths())
+	assert.Equal(t, 3, original.ColumnWidths()["id"], "Should not change earlier copies")
+
+	// Stops at the maximum width
+	model, _ = model.Update(columnWidenKey)
+
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+	assert.Equal(t, 4, model.ColumnWidths()["id"])
+
+	// Stops at the minimum width
+	for i := 0; i < 5; i++ {
+		model, _ = model.Update(columnNarrowKey)
+	}
+
+	assert.Equal(t, 2, model.ColumnWidths()["id"])
+
+	model, _ = model.Update(columnNextKey)
+	model, _ = model.Update(columnWidenKey)
+	model, _ = model.Update(columnWidenKey)
+
+	const expectedTable = `┏━━┳━━━━━━━┓
+┃ID┃   Name┃
+┣━━╋━━━━━━━┫
+┃ 1┃Alexan…┃
+┗━━┻━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestColumnResizeDisabled(t *testing.T) {
+	model := genColumnResizeModel().WithColumnResizing(false)
+
+	model, _ = model.Update(columnWidenKey)
+
+	assert.Equal(t, 3, model.ColumnWidths()["id"])
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestColumnResizeFlexBecomesFixed(t *testing.T) {
+	model := New([]Column{
+		NewFlexColumn("a", "A", 1),
+		NewFlexColumn("b", "B", 1),
+	}).WithTargetWidth(13).WithColumnResizing(true).Focused(true)
+
+	assert.Equal(t, map[string]int{"a": 5, "b": 5}, model.ColumnWidths())
+
+	model, _ = model.Update(columnWidenKey)
+
+	assert.False(t, model.columns[0].IsFlex())
+	assert.True(t, model.columns[1].IsFlex())
+	assert.Equal(t, map[string]int{"a": 6, "b": 4}, model.ColumnWidths())
+}
+
+func TestColumnResizeSkipsSelectColumn(t *testing.T) {
+	model := genColumnResizeModel().SelectableRows(true)
+
+	model, _ = model.Update(columnNarrowKey)
+
+	assert.Equal(t, map[string]int{"id": 2, "name": 5}, model.ColumnWidths())
+}
+
+func TestResizeColumnAndWithColumnWidths(t *testing.T) {
+	model := genColumnResizeModel().ResizeColumn("name", 3).ResizeColumn("missing", 3)
+
+	assert.Equal(t, map[string]int{"id": 3, "name": 8}, model.ColumnWidths())
+
+	restored := genColumnResizeModel().WithColumnWidths(map[string]int{"id": 10, "name": 7, "other": 1})
+
+	assert.Equal(t, map[string]int{"id": 4, "name": 7}, restored.ColumnWidths())
+	assert.Equal(t, 14, restored.totalWidth)
+}
+
+func TestColumnWidthsIncludeHiddenColumns(t *testing.T) {
+	model := genColumnResizeModel().WithHiddenColumns([]string{"name"})
+
+	assert.Equal(t, map[string]int{"id": 3, "name": 5}, model.ColumnWidths())
+
+	model = model.WithColumnWidths(map[string]int{"name": 9})
+
+	assert.Equal(t, map[string]int{"id": 3, "name": 9}, model.ColumnWidths())
+
+	// The width is kept when shown again
+	model = model.WithHiddenColumns(nil)
+
+	assert.Equal(t, 9, model.columns[1].width)
+}
+
+func TestColumnResizeUpdatesOverflow(t *testing.T) {
+	model := New([]Column{
+		NewColumn("a", "A", 3),
+		NewColumn("b", "B", 3),
+		NewColumn("c", "C", 3),
+	}).WithMaxTotalWidth(13).WithColumnResizing(true).Focused(true)
+
+	assert.Equal(t, 0, model.maxHorizontalColumnIndex)
+
+	model, _ = model.Update(columnWidenKey)
+
+	assert.Equal(t, 1, model.maxHorizontalColumnIndex)
+}
@@ -215,3 +215,15 @@ func TestColumnFormatString(t *testing.T) {
 		})
 	}
 }
+
+func TestColumnWidthLimits(t *testing.T) {
+	col := NewPercentColumn("key", "title", 30).WithMinWidth(2).WithMaxWidth(8)
+
+	assert.Equal(t, 30, col.PercentWidth())
+	assert.Equal(t, 2, col.MinWidth())
+	assert.Equal(t, 8, col.MaxWidth())
+	assert.False(t, col.IsFlex())
+
+	assert.Equal(t, 0, NewPercentColumn("key", "title", -5).PercentWidth())
+	assert.Equal(t, 0, NewColumn("key", "title", 3).PercentWidth())
+}
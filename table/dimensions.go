@@ -5,6 +5,8 @@ import (
 )
 
 func (m *Model) recalculateWidth() {
+	m.measureAutoColumns()
+
 	if m.targetTotalWidth != 0 {
 		m.totalWidth = m.targetTotalWidth
 	} else {
@@ -20,29 +22,116 @@ func (m *Model) recalculateWidth() {
 	updateColumnWidths(m.columns, m.targetTotalWidth)
 
 	m.recalculateLastHorizontalColumn()
+
+	m.clampColumnCursor()
+
+	// Detail panels are as wide as the table, so their height may change
+	m.updateDetailPageStarts()
 }
 
 // Updates column width in-place.  This could be optimized but should be called
 // very rarely so we prioritize simplicity over performance here.
 func updateColumnWidths(cols []Column, totalWidth int) {
-	totalFlexWidth := totalWidth - len(cols) - 1
-	totalFlexFactor := 0
-	flexGCD := 0
+	contentWidth := totalWidth - len(cols) - 1
+
+	for index := range cols {
+		if cols[index].percentWidth == 0 {
+			continue
+		}
+
+		if totalWidth > 0 {
+			cols[index].width = cols[index].clampWidth(contentWidth * cols[index].percentWidth / 100)
+		} else {
+			cols[index].width = cols[index].minimumWidth()
+		}
+	}
+
+	if totalWidth > 0 {
+		shrinkAutoColumns(cols, totalWidth)
+	}
+
+	totalFlexWidth := contentWidth
+	flexIndices := []int{}
 
 	for index, col := range cols {
 		if !col.isFlex() {
 			totalFlexWidth -= col.width
 			cols[index].style = col.style.Width(col.width)
 		} else {
-			totalFlexFactor += col.flexFactor
-			flexGCD = gcd(flexGCD, col.flexFactor)
+			flexIndices = append(flexIndices, index)
 		}
 	}
 
-	if totalFlexFactor == 0 {
+	if len(flexIndices) == 0 {
 		return
 	}
 
+	for index, width := range distributeFlexWidth(cols, flexIndices, totalFlexWidth) {
+		cols[index].width = width
+
+		// Take borders into account for the actual style
+		cols[index].style = cols[index].style.Width(width)
+	}
+}
+
+// distributeFlexWidth splits the width between the flex columns at the given
+// indices by their flex factors, keeping each within its minimum and maximum
+// widths.  Columns that hit a limit are fixed at that limit and the rest of
+// the width is split again between the others, the same way that CSS flexbox
+// resolves flexible lengths.  Returns the width of each column by index.
+func distributeFlexWidth(cols []Column, flexIndices []int, totalFlexWidth int) map[int]int {
+	widths := make(map[int]int, len(flexIndices))
+
+	for len(flexIndices) > 0 {
+		shares := shareFlexWidth(cols, flexIndices, totalFlexWidth)
+
+		// Positive if columns need to grow to their minimums more than others
+		// need to shrink to their maximums
+		violation := 0
+
+		for _, index := range flexIndices {
+			violation += cols[index].clampWidth(shares[index]) - shares[index]
+		}
+
+		if violation == 0 {
+			for _, index := range flexIndices {
+				widths[index] = cols[index].clampWidth(shares[index])
+			}
+
+			break
+		}
+
+		unfixed := []int{}
+
+		for _, index := range flexIndices {
+			clamped := cols[index].clampWidth(shares[index])
+
+			if (violation > 0 && clamped > shares[index]) || (violation < 0 && clamped < shares[index]) {
+				widths[index] = clamped
+				totalFlexWidth -= clamped
+			} else {
+				unfixed = append(unfixed, index)
+			}
+		}
+
+		flexIndices = unfixed
+	}
+
+	return widths
+}
+
+// shareFlexWidth splits the width between the flex columns at the given
+// indices by their flex factors alone.  Returns the width of each column by
+// index.
+func shareFlexWidth(cols []Column, flexIndices []int, totalFlexWidth int) map[int]int {
+	totalFlexFactor := 0
+	flexGCD := 0
+
+	for _, index := range flexIndices {
+		totalFlexFactor += cols[index].flexFactor
+		flexGCD = gcd(flexGCD, cols[index].flexFactor)
+	}
+
 	// We use the GCD here because otherwise very large values won't divide
 	// nicely as ints
 	totalFlexFactor /= flexGCD
@@ -50,11 +139,9 @@ func updateColumnWidths(cols []Column, totalWidth int) {
 	flexUnit := totalFlexWidth / totalFlexFactor
 	leftoverWidth := totalFlexWidth % totalFlexFactor
 
-	for index := range cols {
-		if !cols[index].isFlex() {
-			continue
-		}
+	shares := make(map[int]int, len(flexIndices))
 
+	for _, index := range flexIndices {
 		width := flexUnit * (cols[index].flexFactor / flexGCD)
 
 		if leftoverWidth > 0 {
@@ -67,13 +154,10 @@ func updateColumnWidths(cols []Column, totalWidth int) {
 			leftoverWidth = 0
 		}
 
-		width = max(width, 1)
-
-		cols[index].width = width
-
-		// Take borders into account for the actual style
-		cols[index].style = cols[index].style.Width(width)
+		shares[index] = width
 	}
+
+	return shares
 }
 
 func (m *Model) recalculateHeight() {
@@ -89,7 +173,8 @@ func (m *Model) recalculateHeight() {
 		footerHeight = lipgloss.Height(footer)
 	}
 
-	m.metaHeight = headerHeight + footerHeight
+	// The summary row is left out since its divider depends on what's above it
+	m.metaHeight = headerHeight + footerHeight + m.pinnedRowsHeight()
 }
 
 func (m *Model) calculatePadding(numRows int) int {
@@ -137,6 +137,95 @@ func TestColumnUpdateWidths(t *testing.T) {
 				5, 1, 1,
 			},
 		},
+		{
+			name: "Flex with max width gives the rest to others",
+			columns: []Column{
+				NewFlexColumn("abc", "a", 1).WithMaxWidth(4),
+				NewFlexColumn("sdf", "b", 1),
+			},
+			totalWidth: 23,
+			expectedWidths: []int{
+				4, 16,
+			},
+		},
+		{
+			name: "Flex with min width takes from others",
+			columns: []Column{
+				NewFlexColumn("abc", "a", 1).WithMinWidth(8),
+				NewFlexColumn("sdf", "b", 1),
+			},
+			totalWidth: 13,
+			expectedWidths: []int{
+				8, 2,
+			},
+		},
+		{
+			name: "Flex min width outweighs max width",
+			columns: []Column{
+				NewFlexColumn("abc", "a", 1).WithMaxWidth(2),
+				NewFlexColumn("sdf", "b", 1).WithMinWidth(9),
+			},
+			totalWidth: 13,
+			expectedWidths: []int{
+				1, 9,
+			},
+		},
+		{
+			name: "Flex max widths leave space unused",
+			columns: []Column{
+				NewFlexColumn("abc", "a", 1).WithMaxWidth(3),
+				NewFlexColumn("sdf", "b", 2).WithMaxWidth(5),
+			},
+			totalWidth: 23,
+			expectedWidths: []int{
+				3, 5,
+			},
+		},
+		{
+			name: "Width is too small for min widths",
+			columns: []Column{
+				NewFlexColumn("abc", "a", 1).WithMinWidth(4),
+				NewFlexColumn("sdf", "b", 1),
+			},
+			totalWidth: 3,
+			expectedWidths: []int{
+				4, 1,
+			},
+		},
+		{
+			name: "Percent and flex",
+			columns: []Column{
+				NewPercentColumn("abc", "a", 25),
+				NewFlexColumn("sdf", "b", 1),
+			},
+			totalWidth: 23,
+			expectedWidths: []int{
+				5, 15,
+			},
+		},
+		{
+			name: "Percent with max width",
+			columns: []Column{
+				NewPercentColumn("abc", "a", 50).WithMaxWidth(6),
+				NewColumn("sdf", "b", 4),
+				NewFlexColumn("xyz", "c", 1),
+			},
+			totalWidth: 24,
+			expectedWidths: []int{
+				6, 4, 10,
+			},
+		},
+		{
+			name: "Percent without total width uses min width",
+			columns: []Column{
+				NewPercentColumn("abc", "a", 50).WithMinWidth(3),
+				NewColumn("sdf", "b", 4),
+			},
+			totalWidth: 0,
+			expectedWidths: []int{
+				3, 4,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -233,3 +322,32 @@ func TestRecalculateHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestPercentAndConstrainedFlexColumnsInTable(t *testing.T) {
+	model := New([]Column{
+		NewPercentColumn("id", "ID", 20),
+		NewFlexColumn("name", "Name", 1).WithMaxWidth(6),
+		NewFlexColumn("desc", "Desc", 1).WithMinWidth(4),
+	}).WithRows([]Row{
+		NewRow(RowData{"id": 1, "name": "Alexander", "desc": "Something long"}),
+	}).WithTargetWidth(24).WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━┳━━━━━━┳━━━━━━━━━━┓
+┃  ID┃  Name┃      Desc┃
+┣━━━━╋━━━━━━╋━━━━━━━━━━┫
+┃   1┃Alexa…┃Something…┃
+┗━━━━┻━━━━━━┻━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	// Narrow tables keep the minimum width
+	model = model.WithTargetWidth(12)
+
+	assert.Equal(t, map[string]int{"id": 1, "name": 3, "desc": 4}, model.ColumnWidths())
+
+	// Resizing a percentage column makes it fixed
+	model = model.WithTargetWidth(24).ResizeColumn("id", 1)
+
+	assert.Equal(t, 0, model.columns[0].PercentWidth())
+	assert.Equal(t, map[string]int{"id": 5, "name": 6, "desc": 9}, model.ColumnWidths())
+}
@@ -0,0 +1,281 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CellEditor is an embedded editor used to edit the value of a cell in place.
+// The editor works with the text representation of the value, which is then
+// converted by the column's parser when the edit is committed.
+type CellEditor interface {
+	// Value returns the current text in the editor.
+	Value() string
+
+	// SetValue returns a copy of the editor with the given text.
+	SetValue(value string) CellEditor
+
+	// Focus returns a focused copy of the editor, called when editing starts.
+	// It may also return a command such as a cursor blink.
+	Focus() (CellEditor, tea.Cmd)
+
+	// Update handles any messages while editing, except for the commit and
+	// cancel keys which are handled by the table.
+	Update(msg tea.Msg) (CellEditor, tea.Cmd)
+
+	// View renders the editor inside the cell.
+	View() string
+}
+
+// CellEditValidator checks the text in the editor before an edit is committed.
+// If an error is returned, the edit is not committed and the error is shown in
+// the footer.
+type CellEditValidator func(value string) error
+
+// CellEditParser converts the text in the editor into the value that will be
+// stored in the row data.
+type CellEditParser func(value string) (any, error)
+
+type textInputCellEditor struct {
+	input textinput.Model
+}
+
+// NewTextInputCellEditor creates a CellEditor from a text input bubble.  This
+// is the default editor, and can be used to customize the text input such as
+// by setting a character limit.
+func NewTextInputCellEditor(input textinput.Model) CellEditor {
+	return textInputCellEditor{
+		input: input,
+	}
+}
+
+func newDefaultCellEditor(column Column) CellEditor {
+	input := textinput.New()
+	input.Prompt = ""
+
+	// Leave room for the cursor at the end
+	input.Width = max(column.width-1, 1)
+
+	return NewTextInputCellEditor(input)
+}
+
+// Value returns the current text in the text input.
+func (e textInputCellEditor) Value() string {
+	return e.input.Value()
+}
+
+// SetValue returns a copy of the editor with the given text in the text input.
+func (e textInputCellEditor) SetValue(value string) CellEditor {
+	e.input.SetValue(value)
+
+	return e
+}
+
+// Focus returns a copy of the editor with the text input focused.
+func (e textInputCellEditor) Focus() (CellEditor, tea.Cmd) {
+	cmd := e.input.Focus()
+
+	return e, cmd
+}
+
+// Update passes the message through to the text input.
+func (e textInputCellEditor) Update(msg tea.Msg) (CellEditor, tea.Cmd) {
+	var cmd tea.Cmd
+
+	e.input, cmd = e.input.Update(msg)
+
+	return e, cmd
+}
+
+// View renders the text input.
+func (e textInputCellEditor) View() string {
+	return e.input.View()
+}
+
+// isEditingCell returns true if the given cell is currently being edited.
+func (m Model) isEditingCell(row Row, column Column) bool {
+	return m.cellEditor != nil && row.id == m.editRowID && column.key == m.editColumnKey
+}
+
+// startCellEdit opens an editor on the highlighted cell, if the column is
+// editable.
+func (m *Model) startCellEdit() tea.Cmd {
+	if !m.cellCursor || m.visibleRowCount() == 0 {
+		return nil
+	}
+
+	column, ok := m.cursorColumn()
+
+	if !ok || !column.editable {
+		return nil
+	}
+
+	row := m.HighlightedRow()
+
+	if row.groupHeader != nil {
+		return nil
+	}
+
+	newEditor := column.newCellEditor
+
+	if newEditor == nil {
+		newEditor = newDefaultCellEditor
+	}
+
+	editor := newEditor(column)
+
+	current := ""
+
+	if data, exists := row.Data[column.key]; exists {
+		if styled, isStyled := data.(StyledCell); isStyled {
+			data = styled.Data
+		}
+
+		current = fmt.Sprintf("%v", data)
+	}
+
+	editor, cmd := editor.SetValue(current).Focus()
+
+	m.cellEditor = editor
+	m.editRowID = row.id
+	m.editColumnKey = column.key
+	m.editError = nil
+
+	return cmd
+}
+
+func (m *Model) stopCellEdit() {
+	m.cellEditor = nil
+	m.editError = nil
+}
+
+// commitCellEdit validates and parses the editor's value and stores it in the
+// row being edited.  If validation or parsing fails, editing continues and the
+// error is shown in the footer instead.
+func (m *Model) commitCellEdit() {
+	var column Column
+
+	for _, c := range m.columns {
+		if c.key == m.editColumnKey {
+			column = c
+
+			break
+		}
+	}
+
+	text := m.cellEditor.Value()
+
+	if column.editValidator != nil {
+		if err := column.editValidator(text); err != nil {
+			m.editError = err
+
+			return
+		}
+	}
+
+	var newValue any = text
+
+	if column.editParser != nil {
+		parsed, err := column.editParser(text)
+
+		if err != nil {
+			m.editError = err
+
+			return
+		}
+
+		newValue = parsed
+	}
+
+	var (
+		editedRow Row
+		oldValue  any
+	)
+
+	rows, edited := mapRowTree(m.rows, func(row *Row) bool {
+		if row.id != m.editRowID {
+			return false
+		}
+
+		oldValue = row.Data[column.key]
+		storedValue := newValue
+
+		if styled, isStyled := oldValue.(StyledCell); isStyled {
+			// Keep the existing style of the cell
+			oldValue = styled.Data
+			styled.Data = newValue
+			storedValue = styled
+		}
+
+		// Copy the data so that we don't modify any shared row data
+		data := make(RowData, len(row.Data)+1)
+
+		for key, val := range row.Data {
+			data[key] = val
+		}
+
+		data[column.key] = storedValue
+		row.Data = data
+		editedRow = *row
+
+		return true
+	})
+
+	if edited {
+		m.rows = rows
+
+		m.visibleRowCacheUpdated = false
+
+		m.remeasureAutoColumns()
+
+		rowIndex := m.rowCursorIndex
+
+		// Sorting may have moved the row, so keep it highlighted
+		if m.rowSource == nil {
+			displayIndex := m.displayIndexOfInternalID(m.editRowID)
+			rowIndex = -1
+
+			if displayIndex != -1 {
+				m.rowCursorIndex = displayIndex
+				rowIndex = m.rowIndex(displayIndex)
+			}
+
+			m.clampRowCursor()
+		}
+
+		m.appendUserEvent(UserEventCellEdited{
+			RowIndex:  rowIndex,
+			Row:       editedRow,
+			ColumnKey: column.key,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+		})
+	}
+
+	m.stopCellEdit()
+}
+
+func (m Model) updateCellEditor(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, isKey := msg.(tea.KeyMsg); isKey {
+		switch {
+		case key.Matches(keyMsg, m.keyMap.CellEditCancel):
+			m.stopCellEdit()
+
+			return m, nil
+
+		case key.Matches(keyMsg, m.keyMap.CellEditCommit):
+			m.commitCellEdit()
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+
+	m.cellEditor, cmd = m.cellEditor.Update(msg)
+
+	return m, cmd
+}
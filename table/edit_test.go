@@ -0,0 +1,251 @@
+package table
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func genEditModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8).WithEditable(true),
+		NewColumn("count", "Count", 5).
+			WithEditable(true).
+			WithEditParser(func(value string) (any, error) {
+				return strconv.Atoi(value)
+			}),
+		NewColumn("locked", "Locked", 6),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "first", "count": 1, "locked": "no"}),
+		NewRow(RowData{"name": "second", "count": 2, "locked": "no"}),
+	}).Focused(true).WithCellCursor(true)
+}
+
+func typeText(model Model, text string) Model {
+	for _, r := range text {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	return model
+}
+
+func clearEditor(model Model) Model {
+	for i := 0; i < 10; i++ {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+
+	return model
+}
+
+var (
+	keyEdit   = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}
+	keyCommit = tea.KeyMsg{Type: tea.KeyEnter}
+	keyCancel = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestCellEditCommitsText(t *testing.T) {
+	model := genEditModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(keyEdit)
+
+	assert.True(t, model.GetIsEditingCell())
+
+	model = typeText(model, "!")
+
+	assert.True(t, strings.Contains(model.View(), "second!"), "Should show the editor in the cell")
+
+	model, _ = model.Update(keyCommit)
+
+	assert.False(t, model.GetIsEditingCell())
+	assert.Equal(t, "second!", model.HighlightedRow().Data["name"])
+	assert.Equal(t, []UserEvent{
+		UserEventCellEdited{
+			RowIndex:  1,
+			Row:       model.HighlightedRow(),
+			ColumnKey: "name",
+			OldValue:  "second",
+			NewValue:  "second!",
+		},
+	}, model.GetLastUpdateUserEvents())
+}
+
+func TestCellEditCancelLeavesValue(t *testing.T) {
+	model := genEditModel()
+
+	model, _ = model.Update(keyEdit)
+	model = typeText(model, "abc")
+	model, _ = model.Update(keyCancel)
+
+	assert.False(t, model.GetIsEditingCell())
+	assert.Equal(t, "first", model.HighlightedRow().Data["name"])
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+	assert.Equal(t, "", model.GetCurrentFilter(), "Escape should not have reached the filter")
+}
+
+func TestCellEditUsesParser(t *testing.T) {
+	model := genEditModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model, _ = model.Update(keyEdit)
+	model = clearEditor(model)
+	model = typeText(model, "oops")
+	model, _ = model.Update(keyCommit)
+
+	assert.True(t, model.GetIsEditingCell(), "Should still be editing after a parse error")
+	assert.Contains(t, model.View(), "┃strconv.Atoi: parsin…┃")
+	assert.Equal(t, 1, model.HighlightedRow().Data["count"])
+
+	model = clearEditor(model)
+	model = typeText(model, "42")
+	model, _ = model.Update(keyCommit)
+
+	assert.False(t, model.GetIsEditingCell())
+	assert.Equal(t, 42, model.HighlightedRow().Data["count"])
+	assert.NotContains(t, model.View(), "strconv")
+}
+
+func TestCellEditUsesValidator(t *testing.T) {
+	model := genEditModel()
+
+	columns := make([]Column, len(model.columns))
+	copy(columns, model.columns)
+	columns[0] = columns[0].WithEditValidator(func(value string) error {
+		if value == "" {
+			return errors.New("name is required")
+		}
+
+		return nil
+	})
+
+	model = model.WithColumns(columns)
+
+	model, _ = model.Update(keyEdit)
+	model = clearEditor(model)
+	model, _ = model.Update(keyCommit)
+
+	assert.True(t, model.GetIsEditingCell())
+	assert.Contains(t, model.View(), "name is required")
+
+	// Cancelling clears the error
+	model, _ = model.Update(keyCancel)
+
+	assert.NotContains(t, model.View(), "name is required")
+	assert.Equal(t, "first", model.HighlightedRow().Data["name"])
+}
+
+func TestCellEditKeepsStyledCell(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true)
+
+	model := genEditModel().WithRows([]Row{
+		NewRow(RowData{"name": NewStyledCell("styled", style)}),
+	})
+
+	model, _ = model.Update(keyEdit)
+	model = typeText(model, "!")
+	model, _ = model.Update(keyCommit)
+
+	cell, ok := model.HighlightedRow().Data["name"].(StyledCell)
+
+	assert.True(t, ok, "Should still be a styled cell")
+	assert.Equal(t, "styled!", cell.Data)
+	assert.Equal(t, style, cell.Style)
+
+	event, ok := model.GetLastUpdateUserEvents()[0].(UserEventCellEdited)
+
+	assert.True(t, ok)
+	assert.Equal(t, "styled", event.OldValue)
+}
+
+func TestCellEditIgnoredWhenNotEditable(t *testing.T) {
+	model := genEditModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model, _ = model.Update(keyEdit)
+
+	assert.False(t, model.GetIsEditingCell())
+
+	model = genEditModel().WithCellCursor(false)
+	model, _ = model.Update(keyEdit)
+
+	assert.False(t, model.GetIsEditingCell(), "Should need the cell cursor")
+}
+
+type upperCellEditor struct {
+	value string
+}
+
+func (e upperCellEditor) Value() string {
+	return e.value
+}
+
+func (e upperCellEditor) SetValue(value string) CellEditor {
+	e.value = value
+
+	return e
+}
+
+func (e upperCellEditor) Focus() (CellEditor, tea.Cmd) {
+	return e, nil
+}
+
+func (e upperCellEditor) Update(msg tea.Msg) (CellEditor, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.Type == tea.KeyRunes {
+		e.value += strings.ToUpper(string(keyMsg.Runes))
+	}
+
+	return e, nil
+}
+
+func (e upperCellEditor) View() string {
+	return "<" + e.value + ">"
+}
+
+func TestCellEditCustomEditor(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 10).
+			WithEditable(true).
+			WithCellEditor(func(_ Column) CellEditor {
+				return upperCellEditor{}
+			}),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a"}),
+	}).Focused(true).WithCellCursor(true)
+
+	model, _ = model.Update(keyEdit)
+	model = typeText(model, "bc")
+
+	assert.Contains(t, model.View(), "<aBC>")
+
+	model, _ = model.Update(keyCommit)
+
+	assert.Equal(t, "aBC", model.HighlightedRow().Data["name"])
+}
+
+func TestCellEditFollowsSortedRow(t *testing.T) {
+	model := genEditModel().SortByAsc("name")
+
+	model, _ = model.Update(keyEdit)
+	model = clearEditor(model)
+	model = typeText(model, "third")
+	model, _ = model.Update(keyCommit)
+
+	events := model.GetLastUpdateUserEvents()
+
+	if len(events) != 1 {
+		t.Fatalf("Expected one event, got %v", events)
+	}
+
+	event, ok := events[0].(UserEventCellEdited)
+
+	assert.True(t, ok)
+	assert.Equal(t, 1, event.RowIndex, "Should report the index after sorting")
+	assert.Equal(t, 1, model.GetHighlightedRowIndex(), "Should keep the edited row highlighted")
+	assert.Equal(t, "third", model.HighlightedRow().Data["name"])
+}
@@ -49,6 +49,26 @@ type UserEventRowSelectToggled struct {
 	IsSelected bool
 }
 
+// UserEventRowExpandToggled indicates that the user has expanded or collapsed
+// a row with children.
+type UserEventRowExpandToggled struct {
+	// RowIndex is the index of the row in GetVisibleRows.
+	RowIndex int
+
+	// IsExpanded is true if the row's children are now shown.
+	IsExpanded bool
+}
+
+// UserEventRowDetailToggled indicates that the user has shown or hidden the
+// detail panel of a row set by WithRowDetail.
+type UserEventRowDetailToggled struct {
+	// RowIndex is the index of the row in GetVisibleRows.
+	RowIndex int
+
+	// IsExpanded is true if the row's detail panel is now shown.
+	IsExpanded bool
+}
+
 // UserEventFilterInputFocused indicates that the user has focused the filter
 // text input, so that any other typing will type into the filter field.  Only
 // activates for the built-in filter text box.
@@ -58,3 +78,101 @@ type UserEventFilterInputFocused struct{}
 // text input, which means the user is done typing into the filter field.  Only
 // activates for the built-in filter text box.
 type UserEventFilterInputUnfocused struct{}
+
+// UserEventRowClicked indicates that the user has clicked on a row with the
+// mouse.  The clicked row is also highlighted.  Only generated when mouse
+// support is enabled with WithMouseSupport.
+type UserEventRowClicked struct {
+	// RowIndex is the index of the row that was clicked
+	RowIndex int
+
+	// ColumnKey is the key of the column that was clicked within the row
+	ColumnKey string
+}
+
+// UserEventHeaderClicked indicates that the user has clicked on a column header
+// with the mouse.  Only generated when mouse support is enabled with
+// WithMouseSupport.
+type UserEventHeaderClicked struct {
+	// ColumnIndex is the index of the column that was clicked
+	ColumnIndex int
+
+	// ColumnKey is the key of the column that was clicked
+	ColumnKey string
+}
+
+// UserEventSortChanged indicates that the user has changed the sorting of the
+// table, either from the keyboard or by clicking a header.
+type UserEventSortChanged struct {
+	// SortColumns is the new sort order, in the same format as GetColumnSorting
+	SortColumns []SortColumn
+}
+
+// UserEventHighlightedColumnChanged indicates that the user has moved the
+// highlighted cell to a new column.  Only generated when the cell cursor is
+// enabled with WithCellCursor.
+type UserEventHighlightedColumnChanged struct {
+	// PreviousColumnKey is the key of the column that was highlighted before
+	PreviousColumnKey string
+
+	// ColumnKey is the key of the column that is now highlighted
+	ColumnKey string
+}
+
+// UserEventColumnResized indicates that the user has resized a column.  Only
+// generated when resizing is enabled with WithColumnResizing.
+type UserEventColumnResized struct {
+	// ColumnKey is the key of the column that was resized
+	ColumnKey string
+
+	// Width is the new width of the column
+	Width int
+}
+
+// UserEventColumnsChanged indicates that the user has moved, hidden, or shown
+// a column.  Only generated when arranging is enabled with
+// WithColumnArranging.
+type UserEventColumnsChanged struct {
+	// ColumnOrder is the keys of all the columns in order, including hidden
+	// columns
+	ColumnOrder []string
+
+	// HiddenColumnKeys is the keys of the hidden columns in order
+	HiddenColumnKeys []string
+}
+
+// UserEventCellEdited indicates that the user has committed an edit to a cell.
+// Only generated for columns set with WithEditable.
+type UserEventCellEdited struct {
+	// RowIndex is the index of the row that was edited after any sorting has
+	// been applied to the new value, or -1 if the new value hides it from the
+	// current filter
+	RowIndex int
+
+	// Row is the row after the edit was applied
+	Row Row
+
+	// ColumnKey is the key of the column that was edited
+	ColumnKey string
+
+	// OldValue is the value before the edit, or nil if there was no value.  If
+	// the cell was a StyledCell, this is the data within it.
+	OldValue any
+
+	// NewValue is the value after the edit, as returned by the column's parser
+	NewValue any
+}
+
+// UserEventCopied indicates that the user has copied rows or a cell to the
+// clipboard, or tried to and the copy serializer returned an error.
+type UserEventCopied struct {
+	// Text is what was put on the clipboard, or empty if Err is set.
+	Text string
+
+	// RowCount is how many rows were copied.
+	RowCount int
+
+	// Err is the error returned by the copy serializer, if any, in which case
+	// nothing was copied.
+	Err error
+}
@@ -0,0 +1,320 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ExportFormat is the format that rows are written in by Export.
+type ExportFormat int
+
+const (
+	// ExportFormatCSV writes comma separated values with a header row of
+	// column titles.
+	ExportFormatCSV ExportFormat = iota
+
+	// ExportFormatTSV writes tab separated values with a header row of column
+	// titles.
+	ExportFormatTSV
+
+	// ExportFormatJSON writes a JSON array with an object for each row, keyed
+	// by column key.
+	ExportFormatJSON
+
+	// ExportFormatJSONLines writes a JSON object for each row on its own line,
+	// keyed by column key.
+	ExportFormatJSONLines
+
+	// ExportFormatMarkdown writes a Markdown table with a header row of column
+	// titles.
+	ExportFormatMarkdown
+)
+
+// ExportOptions sets what Export writes and how.
+type ExportOptions struct {
+	// Format is the format to write rows in.
+	Format ExportFormat
+
+	// SelectedOnly only writes the rows returned by SelectedRows.
+	SelectedOnly bool
+
+	// Raw writes the raw values from each row's data instead of the text that's
+	// displayed.  JSON keeps the types of raw values, while other formats
+	// write raw values with fmt.Sprint.
+	Raw bool
+}
+
+// ExportedMsg is returned by the command started by the Export key when it's
+// done writing, with any error that happened while writing.
+type ExportedMsg struct {
+	Err error
+}
+
+// Export writes the rows that are currently visible to the writer, in the
+// order that they're shown after filtering and sorting, with the columns in
+// the order that they're shown.  Hidden columns, group headers, pinned rows,
+// and the summary row aren't written.  Missing data is written as empty text,
+// or null in JSON.  When using a row source, only the loaded rows are written.
+func (m *Model) Export(writer io.Writer, options ExportOptions) error {
+	return exportRows(writer, m.exportColumns(), m.exportRowsFor(options), options)
+}
+
+// WithExportTarget sets where rows are written when the user presses the
+// Export key, where open is called each time to get a new writer that's
+// closed after writing.  Writing is done in a command that returns an
+// ExportedMsg when done.  Set open to nil to disable the Export key.
+func (m Model) WithExportTarget(options ExportOptions, open func() (io.WriteCloser, error)) Model {
+	m.exportOptions = options
+	m.exportOpen = open
+
+	return m
+}
+
+// exportCmd returns a command to write the currently visible rows to the
+// export target.
+func (m *Model) exportCmd() tea.Cmd {
+	// Take a snapshot so that later changes don't affect what's written
+	open := m.exportOpen
+	options := m.exportOptions
+	columns := m.exportColumns()
+	rows := m.exportRowsFor(options)
+
+	return func() tea.Msg {
+		writer, err := open()
+
+		if err != nil {
+			return ExportedMsg{Err: err}
+		}
+
+		err = exportRows(writer, columns, rows, options)
+
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+
+		return ExportedMsg{Err: err}
+	}
+}
+
+// exportColumns returns the columns to export, which are all columns other
+// than the selection column.
+func (m *Model) exportColumns() []Column {
+	columns := make([]Column, 0, len(m.columns))
+
+	for _, column := range m.columns {
+		if column.key != columnKeySelect {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+func (m *Model) exportRowsFor(options ExportOptions) []Row {
+	if options.SelectedOnly {
+		return m.SelectedRows()
+	}
+
+	return m.GetVisibleRows()
+}
+
+// exportRows writes the rows to the writer in the chosen format.
+func exportRows(writer io.Writer, columns []Column, rows []Row, options ExportOptions) error {
+	switch options.Format {
+	case ExportFormatCSV:
+		return exportDelimited(writer, columns, rows, options, ',')
+
+	case ExportFormatTSV:
+		return exportDelimited(writer, columns, rows, options, '\t')
+
+	case ExportFormatJSON:
+		return exportJSON(writer, columns, rows, options, false)
+
+	case ExportFormatJSONLines:
+		return exportJSON(writer, columns, rows, options, true)
+
+	case ExportFormatMarkdown:
+		return exportMarkdown(writer, columns, rows, options)
+	}
+
+	return fmt.Errorf("unknown export format %d", options.Format)
+}
+
+// exportValue returns the value of the cell to export, and false if the row
+// is missing data for the column.
+func exportValue(column Column, row Row, raw bool) (any, bool) {
+	data, exists := row.Data[column.key]
+
+	if !exists {
+		return nil, false
+	}
+
+	if styled, isStyled := data.(StyledCell); isStyled {
+		data = styled.Data
+	}
+
+	if raw {
+		return data, true
+	}
+
+	return column.formatData(data), true
+}
+
+// exportText returns the text of the cell to export.
+func exportText(column Column, row Row, raw bool) string {
+	value, exists := exportValue(column, row, raw)
+
+	if !exists {
+		return ""
+	}
+
+	if str, isString := value.(string); isString {
+		return str
+	}
+
+	return fmt.Sprint(value)
+}
+
+func exportDelimited(writer io.Writer, columns []Column, rows []Row, options ExportOptions, delimiter rune) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = delimiter
+
+	record := make([]string, len(columns))
+
+	for i, column := range columns {
+		record[i] = column.title
+	}
+
+	if err := csvWriter.Write(record); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = exportText(column, row, options.Raw)
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// exportJSON writes each row as an object with keys in column order, either
+// in an array or one per line.
+func exportJSON(writer io.Writer, columns []Column, rows []Row, options ExportOptions, lines bool) error {
+	buffer := bytes.Buffer{}
+
+	if !lines {
+		buffer.WriteString("[")
+	}
+
+	for rowIndex, row := range rows {
+		if rowIndex > 0 && !lines {
+			buffer.WriteString(",")
+		}
+
+		buffer.WriteString("{")
+
+		for i, column := range columns {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+
+			key, err := json.Marshal(column.key)
+
+			if err != nil {
+				return err
+			}
+
+			value, _ := exportValue(column, row, options.Raw)
+			encoded, err := json.Marshal(value)
+
+			if err != nil {
+				return fmt.Errorf("failed to encode column %q: %w", column.key, err)
+			}
+
+			buffer.Write(key)
+			buffer.WriteString(":")
+			buffer.Write(encoded)
+		}
+
+		buffer.WriteString("}")
+
+		if lines {
+			buffer.WriteString("\n")
+		}
+	}
+
+	if !lines {
+		buffer.WriteString("]\n")
+	}
+
+	_, err := buffer.WriteTo(writer)
+
+	return err
+}
+
+func exportMarkdown(writer io.Writer, columns []Column, rows []Row, options ExportOptions) error {
+	builder := strings.Builder{}
+
+	writeRow := func(cells []string) {
+		builder.WriteString("|")
+
+		for _, cell := range cells {
+			builder.WriteString(" ")
+			builder.WriteString(escapeMarkdownCell(cell))
+			builder.WriteString(" |")
+		}
+
+		builder.WriteString("\n")
+	}
+
+	cells := make([]string, len(columns))
+
+	for i, column := range columns {
+		cells[i] = column.title
+	}
+
+	writeRow(cells)
+
+	builder.WriteString("|")
+
+	for range columns {
+		builder.WriteString(" --- |")
+	}
+
+	builder.WriteString("\n")
+
+	for _, row := range rows {
+		for i, column := range columns {
+			cells[i] = exportText(column, row, options.Raw)
+		}
+
+		writeRow(cells)
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// escapeMarkdownCell keeps text from breaking out of its table cell.
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"|", `\|`,
+		"\r\n", "<br>",
+		"\n", "<br>",
+	).Replace(text)
+}
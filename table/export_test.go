@@ -0,0 +1,145 @@
+package table
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genExportModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8).WithFiltered(true),
+		NewColumn("size", "Size", 8).WithCellFormatter(FormatBytes),
+		NewColumn("note", "Note", 8),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "b", "size": 2048, "note": "has, comma"}),
+		NewRow(RowData{"name": "a", "size": NewStyledCell(10, defaultHighlightStyle), "note": "pipe|here"}),
+		NewRow(RowData{"name": "c", "size": 1}),
+	}).SortByAsc("name").SelectableRows(true)
+}
+
+func exportString(t *testing.T, model Model, options ExportOptions) string {
+	t.Helper()
+
+	buffer := bytes.Buffer{}
+
+	assert.NoError(t, model.Export(&buffer, options))
+
+	return buffer.String()
+}
+
+func TestExportCSV(t *testing.T) {
+	model := genExportModel()
+
+	assert.Equal(t, "Name,Size,Note\na,10 B,pipe|here\nb,2 KiB,\"has, comma\"\nc,1 B,\n",
+		exportString(t, model, ExportOptions{Format: ExportFormatCSV}))
+
+	assert.Equal(t, "Name,Size,Note\na,10,pipe|here\nb,2048,\"has, comma\"\nc,1,\n",
+		exportString(t, model, ExportOptions{Format: ExportFormatCSV, Raw: true}))
+}
+
+func TestExportTSVFilteredAndSelected(t *testing.T) {
+	model := genExportModel().Filtered(true).WithFilterInputValue("b")
+
+	assert.Equal(t, "Name\tSize\tNote\nb\t2 KiB\thas, comma\n",
+		exportString(t, model, ExportOptions{Format: ExportFormatTSV}))
+
+	model = genExportModel().WithRows([]Row{
+		NewRow(RowData{"name": "x", "size": 1}).Selected(true),
+		NewRow(RowData{"name": "y", "size": 2}),
+	})
+
+	assert.Equal(t, "Name\tSize\tNote\nx\t1\t\n",
+		exportString(t, model, ExportOptions{Format: ExportFormatTSV, SelectedOnly: true, Raw: true}))
+}
+
+func TestExportJSON(t *testing.T) {
+	model := genExportModel()
+
+	assert.Equal(t, `[{"name":"a","size":10,"note":"pipe|here"},{"name":"b","size":2048,"note":"has, comma"},{"name":"c","size":1,"note":null}]`+"\n",
+		exportString(t, model, ExportOptions{Format: ExportFormatJSON, Raw: true}))
+
+	assert.Equal(t, `{"name":"a","size":"10 B","note":"pipe|here"}`+"\n"+
+		`{"name":"b","size":"2 KiB","note":"has, comma"}`+"\n"+
+		`{"name":"c","size":"1 B","note":null}`+"\n",
+		exportString(t, model, ExportOptions{Format: ExportFormatJSONLines}))
+
+	assert.Equal(t, "[]\n", exportString(t, model.WithRows(nil), ExportOptions{Format: ExportFormatJSON}))
+}
+
+func TestExportJSONUnsupportedValue(t *testing.T) {
+	model := genExportModel().WithRows([]Row{NewRow(RowData{"name": func() {}})})
+
+	assert.Error(t, model.Export(io.Discard, ExportOptions{Format: ExportFormatJSON, Raw: true}))
+}
+
+func TestExportMarkdown(t *testing.T) {
+	model := genExportModel().WithColumns([]Column{
+		NewColumn("note", "Note", 8),
+		NewColumn("name", "Name", 8),
+	})
+
+	const expected = `| Note | Name |
+| --- | --- |
+| pipe\|here | a |
+| has, comma | b |
+|  | c |
+`
+
+	assert.Equal(t, expected, exportString(t, model, ExportOptions{Format: ExportFormatMarkdown}))
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	model := genExportModel()
+
+	assert.Error(t, model.Export(io.Discard, ExportOptions{Format: ExportFormat(-1)}))
+}
+
+type nopWriteCloser struct {
+	io.Writer
+	closed bool
+}
+
+func (w *nopWriteCloser) Close() error {
+	w.closed = true
+
+	return nil
+}
+
+func TestExportKey(t *testing.T) {
+	model := genExportModel().Focused(true)
+
+	// Nothing happens without a target
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+
+	assert.Nil(t, cmd)
+
+	buffer := bytes.Buffer{}
+	writer := &nopWriteCloser{Writer: &buffer}
+
+	model = model.WithExportTarget(ExportOptions{Format: ExportFormatCSV, Raw: true}, func() (io.WriteCloser, error) {
+		return writer, nil
+	})
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+
+	assert.NotNil(t, cmd)
+	assert.Empty(t, buffer.String())
+	assert.Equal(t, ExportedMsg{}, cmd())
+	assert.Equal(t, "Name,Size,Note\na,10,pipe|here\nb,2048,\"has, comma\"\nc,1,\n", buffer.String())
+	assert.True(t, writer.closed)
+
+	openErr := errors.New("no space")
+
+	model = model.WithExportTarget(ExportOptions{}, func() (io.WriteCloser, error) {
+		return nil, openErr
+	})
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+
+	assert.Equal(t, ExportedMsg{Err: openErr}, cmd())
+}
@@ -25,29 +25,30 @@ type FilterFuncInput struct {
 // or false if the row should be hidden.
 type FilterFunc func(FilterFuncInput) bool
 
+type filterKind int
+
+const (
+	filterKindContains filterKind = iota
+	filterKindFuzzy
+	filterKindQuery
+	filterKindCustom
+)
+
 func (m Model) getFilteredRows(rows []Row) []Row {
-	filterInputValue := m.filterTextInput.Value()
+	return m.filterRows(rows, m.filterTextInput.Value())
+}
+
+func (m Model) filterRows(rows []Row, filterInputValue string) []Row {
 	if !m.filtered || filterInputValue == "" {
 		return rows
 	}
 
+	m = m.withParsedFilterQuery(filterInputValue)
+
 	filteredRows := make([]Row, 0)
 
 	for _, row := range rows {
-		var availableFilterFunc FilterFunc
-
-		if m.filterFunc != nil {
-			availableFilterFunc = m.filterFunc
-		} else {
-			availableFilterFunc = filterFuncContains
-		}
-
-		if availableFilterFunc(FilterFuncInput{
-			Columns:        m.columns,
-			Row:            row,
-			Filter:         filterInputValue,
-			GlobalMetadata: m.metadata,
-		}) {
+		if m.rowMatchesFilter(row, filterInputValue) {
 			filteredRows = append(filteredRows, row)
 		}
 	}
@@ -55,6 +56,40 @@ func (m Model) getFilteredRows(rows []Row) []Row {
 	return filteredRows
 }
 
+// rowMatchesFilter returns true if the row matches the given filter text.
+func (m Model) rowMatchesFilter(row Row, filterInputValue string) bool {
+	if m.filterKind == filterKindQuery {
+		query, err := m.filterQuery(filterInputValue)
+
+		return err != nil || query.matches(m.columns, row)
+	}
+
+	availableFilterFunc := m.filterFunc
+
+	if availableFilterFunc == nil {
+		availableFilterFunc = filterFuncContains
+	}
+
+	return availableFilterFunc(FilterFuncInput{
+		Columns:        m.columns,
+		Row:            row,
+		Filter:         filterInputValue,
+		GlobalMetadata: m.metadata,
+	})
+}
+
+// isRowVisibleWithFilter returns true if the row would pass the currently
+// applied filter, if any.
+func (m Model) isRowVisibleWithFilter(row Row) bool {
+	filterInputValue := m.appliedFilter()
+
+	if !m.filtered || filterInputValue == "" {
+		return true
+	}
+
+	return m.rowMatchesFilter(row, filterInputValue)
+}
+
 // filterFuncContains returns a filterFunc that performs case-insensitive
 // "contains" matching over all filterable columns in a row.
 func filterFuncContains(input FilterFuncInput) bool {
@@ -79,30 +114,32 @@ func filterFuncContains(input FilterFuncInput) bool {
 			continue
 		}
 
-		// Extract internal StyledCell data
-		switch dataV := data.(type) {
-		case StyledCell:
-			data = dataV.Data
+		if strings.Contains(strings.ToLower(filterString(data)), filterLower) {
+			return true
 		}
+	}
 
-		var target string
-		switch dataV := data.(type) {
-		case string:
-			target = dataV
+	return !checkedAny
+}
 
-		case fmt.Stringer:
-			target = dataV.String()
+// filterString returns the text of the data to match a filter against.
+func filterString(data any) string {
+	// Extract internal StyledCell data
+	switch dataV := data.(type) {
+	case StyledCell:
+		data = dataV.Data
+	}
 
-		default:
-			target = fmt.Sprintf("%v", data)
-		}
+	switch dataV := data.(type) {
+	case string:
+		return dataV
 
-		if strings.Contains(strings.ToLower(target), filterLower) {
-			return true
-		}
-	}
+	case fmt.Stringer:
+		return dataV.String()
 
-	return !checkedAny
+	default:
+		return fmt.Sprintf("%v", data)
+	}
 }
 
 // filterFuncFuzzy returns a filterFunc that performs case-insensitive fuzzy
@@ -0,0 +1,254 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterMatchMask returns which runes of the cell's displayed text match the
+// applied filter, or nil if there's nothing to highlight.  Highlighting works
+// with the built-in filters, but not with custom filter functions.
+func (m Model) filterMatchMask(row Row, column Column, str string) []bool {
+	filter := m.appliedFilter()
+
+	if !m.filterMatchHighlight || !m.filtered || m.multiline || filter == "" {
+		return nil
+	}
+
+	switch m.filterKind {
+	case filterKindContains:
+		// Matching is against the unformatted data, so formatted text can't
+		// be highlighted
+		if !column.filterable || filterString(row.Data[column.key]) != str {
+			return nil
+		}
+
+		return substringMatchMask(str, filter)
+
+	case filterKindFuzzy:
+		return m.fuzzyMatchMask(row, column, str, filter)
+
+	case filterKindQuery:
+		return m.queryMatchMask(row, column, str, filter)
+	}
+
+	return nil
+}
+
+// substringMatchMask marks every case-insensitive occurrence of the needle.
+func substringMatchMask(str string, needle string) []bool {
+	runes := []rune(str)
+	needleRunes := []rune(strings.ToLower(needle))
+	mask := make([]bool, len(runes))
+
+	if len(needleRunes) == 0 {
+		return mask
+	}
+
+	for start := 0; start+len(needleRunes) <= len(runes); start++ {
+		matches := true
+
+		for i, needleRune := range needleRunes {
+			if unicode.ToLower(runes[start+i]) != needleRune {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			for i := range needleRunes {
+				mask[start+i] = true
+			}
+		}
+	}
+
+	return mask
+}
+
+// fuzzyMatchMask marks the characters that filterFuncFuzzy matched in this
+// cell, which means repeating its search over all filterable columns.
+func (m Model) fuzzyMatchMask(row Row, column Column, str string, filter string) []bool {
+	haystack := []rune{}
+	cellStart := -1
+	cellLength := 0
+
+	for _, col := range m.columns {
+		if !col.filterable {
+			continue
+		}
+
+		value, ok := row.Data[col.key]
+
+		if !ok {
+			continue
+		}
+
+		if sc, ok := value.(StyledCell); ok {
+			value = sc.Data
+		}
+
+		text := []rune(fmt.Sprint(value))
+
+		if col.key == column.key {
+			if string(text) != str {
+				// The displayed text is formatted differently, so we can't
+				// tell which characters matched
+				return nil
+			}
+
+			cellStart = len(haystack)
+			cellLength = len(text)
+		}
+
+		for _, r := range text {
+			haystack = append(haystack, unicode.ToLower(r))
+		}
+
+		haystack = append(haystack, ' ')
+	}
+
+	if cellStart == -1 {
+		return nil
+	}
+
+	matched := make([]bool, len(haystack))
+
+	for _, token := range strings.Fields(strings.ToLower(filter)) {
+		positions := []int{}
+		needle := []rune(token)
+
+		for i := 0; i < len(haystack) && len(positions) < len(needle); i++ {
+			if haystack[i] == needle[len(positions)] {
+				positions = append(positions, i)
+			}
+		}
+
+		if len(positions) < len(needle) {
+			return nil
+		}
+
+		for _, position := range positions {
+			matched[position] = true
+		}
+	}
+
+	return matched[cellStart : cellStart+cellLength]
+}
+
+// queryMatchMask marks text and regex matches from the terms of whichever
+// query groups the row matched.
+func (m Model) queryMatchMask(row Row, column Column, str string, filter string) []bool {
+	query, err := m.filterQuery(filter)
+
+	if err != nil {
+		return nil
+	}
+
+	mask := make([]bool, utf8.RuneCountInString(str))
+
+	for _, group := range query.groups {
+		if !(filterQuery{groups: [][]queryTerm{group}}).matches(m.columns, row) {
+			continue
+		}
+
+		for _, term := range group {
+			if term.negate {
+				continue
+			}
+
+			if term.column != "" {
+				if termColumn, found := findQueryColumn(m.columns, term.column); !found || termColumn.key != column.key {
+					continue
+				}
+			} else if !column.filterable {
+				continue
+			}
+
+			switch {
+			case term.regex != nil:
+				for _, match := range term.regex.FindAllStringIndex(str, -1) {
+					start := utf8.RuneCountInString(str[:match[0]])
+					end := start + utf8.RuneCountInString(str[match[0]:match[1]])
+
+					for i := start; i < end; i++ {
+						mask[i] = true
+					}
+				}
+
+			case term.op == ":" && term.text != "":
+				for i, matched := range substringMatchMask(str, term.text) {
+					mask[i] = mask[i] || matched
+				}
+
+			case term.op == "=" && strings.EqualFold(str, term.text):
+				for i := range mask {
+					mask[i] = true
+				}
+			}
+		}
+	}
+
+	return mask
+}
+
+// renderFilterMatches truncates the text to the column width, then styles the
+// matching characters that are still visible.  The rest of the text keeps the
+// cell's style, since the match styling would otherwise reset it.
+func (m Model) renderFilterMatches(str string, mask []bool, width int, cellStyle lipgloss.Style) string {
+	truncated := limitStr(str, width)
+	runes := []rune(truncated)
+	tail := ""
+
+	if truncated != str && strings.HasSuffix(truncated, "…") {
+		runes = runes[:len(runes)-1]
+		tail = "…"
+	}
+
+	anyVisible := false
+
+	for i := range runes {
+		if i < len(mask) && mask[i] {
+			anyVisible = true
+
+			break
+		}
+	}
+
+	if !anyVisible {
+		return truncated
+	}
+
+	textStyle := cellStyle.Copy().Inline(true).UnsetWidth().UnsetHeight().UnsetMaxWidth()
+	matchStyle := m.filterMatchStyle.Copy().Inherit(textStyle)
+
+	builder := strings.Builder{}
+	start := 0
+
+	for start < len(runes) {
+		isMatch := start < len(mask) && mask[start]
+		end := start + 1
+
+		for end < len(runes) && (end < len(mask) && mask[end]) == isMatch {
+			end++
+		}
+
+		segment := string(runes[start:end])
+
+		if isMatch {
+			builder.WriteString(matchStyle.Render(segment))
+		} else {
+			builder.WriteString(textStyle.Render(segment))
+		}
+
+		start = end
+	}
+
+	builder.WriteString(textStyle.Render(tail))
+
+	return builder.String()
+}
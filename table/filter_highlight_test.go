@@ -0,0 +1,143 @@
+package table
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+var ansiEscapePattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func genFilterHighlightModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("status", "Status", 10).WithFiltered(true),
+		NewColumn("notes", "Notes", 10),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "Banana", "status": NewStyledCell("ripe", defaultHighlightStyle), "notes": "banana"}),
+	}).Filtered(true).WithFilterMatchHighlight(true)
+}
+
+// maskString shows the mask as a string where matches are ^ for easy reading
+func maskString(mask []bool) string {
+	if mask == nil {
+		return "<nil>"
+	}
+
+	str := ""
+
+	for _, matched := range mask {
+		if matched {
+			str += "^"
+		} else {
+			str += " "
+		}
+	}
+
+	return str
+}
+
+func TestFilterMatchMaskContains(t *testing.T) {
+	model := genFilterHighlightModel().WithFilterInputValue("AN")
+	row := model.rows[0]
+
+	assert.Equal(t, " ^^^^ ", maskString(model.filterMatchMask(row, model.columns[0], "Banana")))
+
+	// Columns that aren't filtered aren't highlighted
+	assert.Equal(t, "<nil>", maskString(model.filterMatchMask(row, model.columns[2], "banana")))
+
+	// Formatted text doesn't match what was filtered
+	assert.Equal(t, "<nil>", maskString(model.filterMatchMask(row, model.columns[0], "Banana (an)")))
+}
+
+func TestFilterMatchMaskFuzzy(t *testing.T) {
+	model := genFilterHighlightModel().WithFuzzyFilter().WithFilterInputValue("bnn re")
+	row := model.rows[0]
+
+	assert.Equal(t, "^ ^ ^ ", maskString(model.filterMatchMask(row, model.columns[0], "Banana")))
+	assert.Equal(t, "^  ^", maskString(model.filterMatchMask(row, model.columns[1], "ripe")))
+
+	// Formatted text can't be matched back to the filtered text
+	assert.Equal(t, "<nil>", maskString(model.filterMatchMask(row, model.columns[0], "Banana!")))
+}
+
+func TestFilterMatchMaskQuery(t *testing.T) {
+	tests := []struct {
+		filter         string
+		expectedName   string
+		expectedStatus string
+	}{
+		{"an", " ^^^^ ", "    "},
+		{"name:an", " ^^^^ ", "    "},
+		{"status=ripe", "      ", "^^^^"},
+		{"-status:x nan", "  ^^^ ", "    "},
+		{"/a.a/", " ^^^  ", "    "},
+		{"missing OR name:b", "^     ", "    "},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			model := genFilterHighlightModel().WithQueryFilter().WithFilterInputValue(test.filter)
+			row := model.rows[0]
+
+			assert.Equal(t, test.expectedName, maskString(model.filterMatchMask(row, model.columns[0], "Banana")))
+			assert.Equal(t, test.expectedStatus, maskString(model.filterMatchMask(row, model.columns[1], "ripe")))
+		})
+	}
+}
+
+func TestFilterMatchMaskDisabled(t *testing.T) {
+	model := genFilterHighlightModel().WithFilterInputValue("an")
+	row := model.rows[0]
+
+	assert.Equal(t, "<nil>", maskString(model.WithFilterMatchHighlight(false).filterMatchMask(row, model.columns[0], "Banana")))
+	assert.Equal(t, "<nil>", maskString(model.WithMultiline(true).filterMatchMask(row, model.columns[0], "Banana")))
+	assert.Equal(t, "<nil>", maskString(model.WithFilterInputValue("").filterMatchMask(row, model.columns[0], "Banana")))
+
+	custom := model.WithFilterFunc(func(FilterFuncInput) bool { return true })
+
+	assert.Equal(t, "<nil>", maskString(custom.filterMatchMask(row, model.columns[0], "Banana")))
+}
+
+func TestFilterMatchHighlightKeepsView(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 6).WithFiltered(true),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a long name"}),
+		NewRow(RowData{"name": NewStyledCell("ab", defaultHighlightStyle)}),
+	}).Filtered(true).WithFilterInputValue("a").WithFooterVisibility(false)
+
+	highlighted := model.WithFilterMatchHighlight(true).View()
+
+	assert.NotEqual(t, model.View(), highlighted)
+	assert.Contains(t, highlighted, "\x1b[1;4;4ma\x1b[0m lon…")
+
+	// Only the styling should be different
+	assert.Equal(t, model.View(), ansiEscapePattern.ReplaceAllString(highlighted, ""))
+}
+
+func TestRenderFilterMatchesTruncates(t *testing.T) {
+	model := genFilterHighlightModel().WithFilterMatchStyle(lipgloss.NewStyle().Bold(true))
+
+	rendered := model.renderFilterMatches("abcdefgh", maskFromString("  ^^^^^^"), 5, model.baseStyle)
+
+	assert.Equal(t, "ab\x1b[1mcd\x1b[0m…", rendered)
+
+	// Nothing visible to highlight
+	rendered = model.renderFilterMatches("abcdefgh", maskFromString("      ^^"), 5, model.baseStyle)
+
+	assert.Equal(t, "abcd…", rendered)
+}
+
+// maskFromString is the inverse of maskString
+func maskFromString(str string) []bool {
+	mask := make([]bool, len(str))
+
+	for i, r := range str {
+		mask[i] = r == '^'
+	}
+
+	return mask
+}
@@ -0,0 +1,445 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// queryTerm is a single condition in a filter query, such as `cpu>50`.
+type queryTerm struct {
+	negate bool
+
+	// column is empty to match any filterable column
+	column string
+
+	// op is one of : = > >= < <=
+	op string
+
+	// text is lowercase for case-insensitive matching
+	text   string
+	number float64
+	regex  *regexp.Regexp
+}
+
+// filterQuery matches rows that match all terms of any one of its groups,
+// where groups are separated by OR.
+type filterQuery struct {
+	groups [][]queryTerm
+}
+
+// parsedFilterQuery is a filter query that was parsed ahead of time for a pass
+// over many rows, such as filtering or rendering, so that the same filter
+// isn't parsed again for every row.
+type parsedFilterQuery struct {
+	filter string
+	query  filterQuery
+	err    error
+	parsed bool
+}
+
+// withParsedFilterQuery returns a copy of the model that has the query for the
+// given filter parsed ahead of time, if the query filter is being used.
+func (m Model) withParsedFilterQuery(filter string) Model {
+	alreadyParsed := m.parsedFilterQuery.parsed && m.parsedFilterQuery.filter == filter
+
+	if m.filterKind == filterKindQuery && filter != "" && !alreadyParsed {
+		query, err := parseFilterQuery(filter)
+
+		m.parsedFilterQuery = parsedFilterQuery{
+			filter: filter,
+			query:  query,
+			err:    err,
+			parsed: true,
+		}
+	}
+
+	return m
+}
+
+// filterQuery returns the parsed query for the given filter, using the query
+// parsed ahead of time if there is one.
+func (m Model) filterQuery(filter string) (filterQuery, error) {
+	if m.parsedFilterQuery.parsed && m.parsedFilterQuery.filter == filter {
+		return m.parsedFilterQuery.query, m.parsedFilterQuery.err
+	}
+
+	return parseFilterQuery(filter)
+}
+
+// filterFuncQuery is a FilterFunc that matches rows against a filter query.
+// Invalid queries don't hide any rows, and the error is shown in the footer.
+// The table itself uses the query parsed ahead of time instead.
+func filterFuncQuery(input FilterFuncInput) bool {
+	query, err := parseFilterQuery(input.Filter)
+
+	if err != nil {
+		return true
+	}
+
+	return query.matches(input.Columns, input.Row)
+}
+
+// queryFilterError returns any error in the current filter query, if the query
+// filter is being used.
+func (m Model) queryFilterError() error {
+	if m.filterKind != filterKindQuery || !m.filtered || m.filterTextInput.Value() == "" {
+		return nil
+	}
+
+	query, err := m.filterQuery(m.filterTextInput.Value())
+
+	if err != nil {
+		return err
+	}
+
+	for _, group := range query.groups {
+		for _, term := range group {
+			if term.column == "" {
+				continue
+			}
+
+			if _, found := findQueryColumn(m.columns, term.column); !found {
+				return fmt.Errorf("unknown column %q", term.column)
+			}
+		}
+	}
+
+	return nil
+}
+
+func findQueryColumn(columns []Column, name string) (Column, bool) {
+	for _, column := range columns {
+		if column.key != columnKeySelect &&
+			(strings.EqualFold(column.key, name) || strings.EqualFold(column.title, name)) {
+			return column, true
+		}
+	}
+
+	return Column{}, false
+}
+
+func (q filterQuery) matches(columns []Column, row Row) bool {
+	for _, group := range q.groups {
+		matchesAll := true
+
+		for _, term := range group {
+			if term.matches(columns, row) == term.negate {
+				matchesAll = false
+
+				break
+			}
+		}
+
+		if matchesAll {
+			return true
+		}
+	}
+
+	return len(q.groups) == 0
+}
+
+func (t queryTerm) matches(columns []Column, row Row) bool {
+	if t.column != "" {
+		column, found := findQueryColumn(columns, t.column)
+
+		if !found {
+			return false
+		}
+
+		return t.matchesData(row.Data[column.key])
+	}
+
+	for _, column := range columns {
+		if column.filterable && t.matchesData(row.Data[column.key]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t queryTerm) matchesData(data any) bool {
+	if data == nil {
+		return false
+	}
+
+	switch t.op {
+	case ">", ">=", "<", "<=":
+		value, ok := queryNumber(data)
+
+		if !ok {
+			return false
+		}
+
+		switch t.op {
+		case ">":
+			return value > t.number
+		case ">=":
+			return value >= t.number
+		case "<":
+			return value < t.number
+		default:
+			return value <= t.number
+		}
+	}
+
+	target := filterString(data)
+
+	if t.regex != nil {
+		return t.regex.MatchString(target)
+	}
+
+	if t.op == "=" {
+		if value, ok := queryNumber(data); ok {
+			if number, err := strconv.ParseFloat(t.text, 64); err == nil {
+				return value == number
+			}
+		}
+
+		return strings.EqualFold(target, t.text)
+	}
+
+	return strings.Contains(strings.ToLower(target), t.text)
+}
+
+// queryNumber converts the data to a number, also allowing numbers stored as
+// strings such as from a CSV file.
+func queryNumber(data any) (float64, bool) {
+	if value, ok := asNumber(data); ok {
+		return value, true
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(filterString(data)), 64)
+
+	return value, err == nil
+}
+
+// queryParser turns filter text into a filterQuery.  The syntax is:
+//
+//	word            any filterable column contains the word
+//	"some phrase"   any filterable column contains the phrase
+//	/regex/         any filterable column matches the regex
+//	col:value       the column contains the value, which may also be quoted or a regex
+//	col=value       the column equals the value
+//	col>5           the column is a number greater than 5, also >=, <, and <=
+//	-term           the term doesn't match
+//	a OR b          either side matches, where terms without OR must all match
+type queryParser struct {
+	input []rune
+	pos   int
+}
+
+func parseFilterQuery(filter string) (filterQuery, error) {
+	parser := queryParser{input: []rune(filter)}
+	query := filterQuery{}
+	group := []queryTerm{}
+
+	for {
+		parser.skipSpaces()
+
+		if parser.done() {
+			break
+		}
+
+		if parser.atKeyword("OR") {
+			if len(group) == 0 {
+				return filterQuery{}, errors.New("OR needs a term before it")
+			}
+
+			query.groups = append(query.groups, group)
+			group = []queryTerm{}
+
+			continue
+		}
+
+		term, err := parser.parseTerm()
+
+		if err != nil {
+			return filterQuery{}, err
+		}
+
+		group = append(group, term)
+	}
+
+	if len(group) == 0 {
+		if len(query.groups) > 0 {
+			return filterQuery{}, errors.New("OR needs a term after it")
+		}
+
+		return query, nil
+	}
+
+	query.groups = append(query.groups, group)
+
+	return query, nil
+}
+
+func (p *queryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *queryParser) peek() rune {
+	return p.input[p.pos]
+}
+
+func (p *queryParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// atKeyword consumes the keyword if it's the next word.
+func (p *queryParser) atKeyword(keyword string) bool {
+	end := p.pos + len(keyword)
+
+	if end > len(p.input) || string(p.input[p.pos:end]) != keyword {
+		return false
+	}
+
+	if end < len(p.input) && !unicode.IsSpace(p.input[end]) {
+		return false
+	}
+
+	p.pos = end
+
+	return true
+}
+
+func isQueryOperatorRune(r rune) bool {
+	return r == ':' || r == '=' || r == '>' || r == '<'
+}
+
+func (p *queryParser) parseTerm() (queryTerm, error) {
+	term := queryTerm{op: ":"}
+
+	if p.peek() == '-' && p.pos+1 < len(p.input) && !unicode.IsSpace(p.input[p.pos+1]) {
+		term.negate = true
+		p.pos++
+	}
+
+	if p.peek() != '"' && p.peek() != '/' {
+		start := p.pos
+
+		for !p.done() && !unicode.IsSpace(p.peek()) && !isQueryOperatorRune(p.peek()) {
+			p.pos++
+		}
+
+		if p.done() || !isQueryOperatorRune(p.peek()) {
+			// Just a plain word
+			term.text = strings.ToLower(string(p.input[start:p.pos]))
+
+			return term, nil
+		}
+
+		term.column = string(p.input[start:p.pos])
+		term.op = p.parseOperator()
+
+		if term.column == "" {
+			return queryTerm{}, fmt.Errorf("%q needs a column name before it", term.op)
+		}
+	}
+
+	err := p.parseValue(&term)
+
+	return term, err
+}
+
+func (p *queryParser) parseOperator() string {
+	op := string(p.peek())
+	p.pos++
+
+	if (op == ">" || op == "<") && !p.done() && p.peek() == '=' {
+		op += "="
+		p.pos++
+	}
+
+	return op
+}
+
+func (p *queryParser) parseValue(term *queryTerm) error {
+	var value string
+
+	switch {
+	case p.done() || unicode.IsSpace(p.peek()):
+		if term.op == ":" || term.op == "=" {
+			// Allows searching for an empty value, and avoids errors while
+			// someone is still typing
+			return nil
+		}
+
+		return fmt.Errorf("%s%s needs a number", term.column, term.op)
+
+	case p.peek() == '"':
+		value = p.readDelimited('"')
+
+	case p.peek() == '/' && (term.op == ":" || term.op == "="):
+		pattern := p.readDelimited('/')
+		regex, err := regexp.Compile("(?i)" + pattern)
+
+		if err != nil {
+			return fmt.Errorf("invalid regex /%s/: %w", pattern, err)
+		}
+
+		term.regex = regex
+
+		return nil
+
+	default:
+		start := p.pos
+
+		for !p.done() && !unicode.IsSpace(p.peek()) {
+			p.pos++
+		}
+
+		value = string(p.input[start:p.pos])
+	}
+
+	term.text = strings.ToLower(value)
+
+	if term.op == ":" || term.op == "=" {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		return fmt.Errorf("%s%s needs a number, not %q", term.column, term.op, value)
+	}
+
+	term.number = number
+
+	return nil
+}
+
+// readDelimited reads text between the delimiter at the current position and
+// the next unescaped delimiter, or the end of the input if there isn't one.
+func (p *queryParser) readDelimited(delimiter rune) string {
+	builder := strings.Builder{}
+
+	// Skip the opening delimiter
+	p.pos++
+
+	for !p.done() {
+		r := p.peek()
+		p.pos++
+
+		if r == '\\' && !p.done() && p.peek() == delimiter {
+			builder.WriteRune(delimiter)
+			p.pos++
+
+			continue
+		}
+
+		if r == delimiter {
+			break
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
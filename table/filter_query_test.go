@@ -0,0 +1,113 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func genQueryFilterModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 10).WithFiltered(true),
+		NewColumn("status", "Status", 10).WithFiltered(true),
+		NewColumn("cpu", "Load", 5),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "nginx-1", "status": "Running", "cpu": 72}),
+		NewRow(RowData{"name": "nginx-2", "status": "Pending", "cpu": 5.5}),
+		NewRow(RowData{"name": "redis", "status": NewStyledCell("Running", defaultHighlightStyle), "cpu": "40"}),
+		NewRow(RowData{"name": "big cache", "status": "Failed", "cpu": 99}),
+	}).Filtered(true).WithQueryFilter()
+}
+
+func TestQueryFilter(t *testing.T) {
+	tests := []struct {
+		filter   string
+		expected []string
+	}{
+		{"", []string{"nginx-1", "nginx-2", "redis", "big cache"}},
+		{"nginx", []string{"nginx-1", "nginx-2"}},
+		{"NGINX running", []string{"nginx-1"}},
+		{"-nginx", []string{"redis", "big cache"}},
+		{`"big cache"`, []string{"big cache"}},
+		{`"g c"`, []string{"big cache"}},
+		{"status:running", []string{"nginx-1", "redis"}},
+		{"Status:run -name:redis", []string{"nginx-1"}},
+		{"status=Running", []string{"nginx-1", "redis"}},
+		{"status=run", []string{}},
+		{"cpu>50", []string{"nginx-1", "big cache"}},
+		{"cpu>=72", []string{"nginx-1", "big cache"}},
+		{"cpu<40", []string{"nginx-2"}},
+		{"cpu<=40", []string{"nginx-2", "redis"}},
+		{"cpu=5.5", []string{"nginx-2"}},
+		{"LOAD>90", []string{"big cache"}},
+		{"/^nginx-\\d$/", []string{"nginx-1", "nginx-2"}},
+		{"name:/S$/", []string{"redis"}},
+		{"redis OR status:failed", []string{"redis", "big cache"}},
+		{"nginx cpu>50 OR big", []string{"nginx-1", "big cache"}},
+		{`name:"big`, []string{"big cache"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			model := genQueryFilterModel().WithFilterInputValue(test.filter)
+
+			names := []string{}
+
+			for _, row := range model.GetVisibleRows() {
+				names = append(names, row.Data["name"].(string))
+			}
+
+			assert.Equal(t, test.expected, names)
+			assert.NoError(t, model.queryFilterError())
+		})
+	}
+}
+
+func TestQueryFilterErrors(t *testing.T) {
+	tests := []struct {
+		filter        string
+		expectedError string
+	}{
+		{"cpu>", "cpu> needs a number"},
+		{"cpu>abc", `cpu> needs a number, not "abc"`},
+		{">5", `">" needs a column name before it`},
+		{"name:/[a/", "invalid regex /[a/: error parsing regexp: missing closing ]: `[a`"},
+		{"OR redis", "OR needs a term before it"},
+		{"redis OR", "OR needs a term after it"},
+		{"missing:value", `unknown column "missing"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			model := genQueryFilterModel().WithFilterInputValue(test.filter)
+
+			err := model.queryFilterError()
+
+			if assert.Error(t, err) {
+				assert.Equal(t, test.expectedError, err.Error())
+			}
+
+			// Invalid queries shouldn't hide anything
+			if test.expectedError != `unknown column "missing"` {
+				assert.Len(t, model.GetVisibleRows(), 4)
+			}
+		})
+	}
+}
+
+func TestQueryFilterErrorInFooter(t *testing.T) {
+	model := New([]Column{
+		NewColumn("cpu", "CPU", 40).WithFiltered(true),
+	}).Filtered(true).WithQueryFilter().WithFilterInputValue("cpu>x")
+
+	assert.Contains(t, model.View(), `cpu> needs a number, not "x"`)
+
+	model = model.WithFilterInputValue("cpu>1")
+
+	assert.NotContains(t, model.View(), "needs a number")
+
+	// Other filters don't use the query language
+	model = model.WithFuzzyFilter().WithFilterInputValue("cpu>x")
+
+	assert.NotContains(t, model.View(), "needs a number")
+}
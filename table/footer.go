@@ -6,7 +6,7 @@ import (
 )
 
 func (m Model) hasFooter() bool {
-	return m.footerVisible && (m.staticFooter != "" || m.pageSize != 0 || m.filtered)
+	return m.footerVisible && (m.staticFooter != "" || m.pageSize != 0 || m.filtered || m.editError != nil || m.rowSourceError != nil)
 }
 
 func (m Model) renderFooter(width int, includeTop bool) string {
@@ -22,16 +22,33 @@ func (m Model) renderFooter(width int, includeTop bool) string {
 		styleFooter = styleFooter.BorderTop(true)
 	}
 
+	// Edit errors need to be seen immediately, so they take over the footer
+	if m.editError != nil {
+		return styleFooter.Render(limitStr(m.editError.Error(), width-borderAdjustment))
+	}
+
+	if m.rowSourceError != nil {
+		return styleFooter.Render(limitStr(m.rowSourceError.Error(), width-borderAdjustment))
+	}
+
 	if m.staticFooter != "" {
 		return styleFooter.Render(m.staticFooter)
 	}
 
 	sections := []string{}
 
-	if m.filtered && (m.filterTextInput.Focused() || m.filterTextInput.Value() != "") {
+	if m.filtered && !m.rowSourceIgnoresFilter() && (m.filterTextInput.Focused() || m.filterTextInput.Value() != "") {
 		sections = append(sections, m.filterTextInput.View())
 	}
 
+	if err := m.queryFilterError(); err != nil {
+		sections = append(sections, err.Error())
+	}
+
+	if m.GetIsFiltering() {
+		sections = append(sections, asyncFilterIndicator)
+	}
+
 	// paged feature enabled
 	if m.pageSize != 0 {
 		str := fmt.Sprintf("%d/%d", m.CurrentPage(), m.MaxPages())
@@ -0,0 +1,287 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CellFormatter turns the raw data of a cell into the text that's displayed.
+// StyledCell values are unwrapped before being passed in.  Only the displayed
+// text is changed, so sorting and numeric filtering still use the raw data.
+type CellFormatter func(data any) string
+
+// formatData returns the text displayed for the given raw data in the column.
+func (c Column) formatData(data any) string {
+	if c.formatter != nil {
+		return c.formatter(data)
+	}
+
+	if c.fmtString != "" {
+		return fmt.Sprintf(c.fmtString, data)
+	}
+
+	return fmt.Sprintf("%v", data)
+}
+
+// formatSummary returns the text shown in the summary row for the result of
+// the column's aggregate.
+func (c Column) formatSummary(value any) string {
+	switch {
+	case c.summaryFormatter != nil:
+		return c.summaryFormatter(value)
+
+	case value == nil:
+		return ""
+
+	case c.formatter != nil, c.fmtString != "":
+		return c.formatData(value)
+	}
+
+	return formatAggregate(value)
+}
+
+// FormatBytes is a CellFormatter that shows a number of bytes in binary units,
+// such as "1.5 KiB" or "20 MiB".  Data that isn't a number is shown as is.
+func FormatBytes(data any) string {
+	const unit = 1024
+
+	bytes, ok := asNumber(data)
+
+	if !ok {
+		return fmt.Sprint(data)
+	}
+
+	sign := ""
+
+	if bytes < 0 {
+		sign = "-"
+		bytes = -bytes
+	}
+
+	if bytes < unit {
+		return fmt.Sprintf("%s%s B", sign, trimDecimals(bytes, 1))
+	}
+
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	exponent := 0
+
+	bytes /= unit
+
+	for bytes >= unit && exponent < len(units)-1 {
+		bytes /= unit
+		exponent++
+	}
+
+	return fmt.Sprintf("%s%s %s", sign, trimDecimals(bytes, 1), units[exponent])
+}
+
+// FormatDuration returns a CellFormatter that shows a time.Duration rounded to
+// the given precision, such as "1h2m3s" for a precision of time.Second.
+// Numbers are treated as a number of seconds.  Other data is shown as is.
+func FormatDuration(precision time.Duration) CellFormatter {
+	return func(data any) string {
+		var duration time.Duration
+
+		switch value := data.(type) {
+		case time.Duration:
+			duration = value
+
+		default:
+			seconds, ok := asNumber(data)
+
+			if !ok {
+				return fmt.Sprint(data)
+			}
+
+			duration = time.Duration(seconds * float64(time.Second))
+		}
+
+		if precision > 0 {
+			duration = duration.Round(precision)
+		}
+
+		return duration.String()
+	}
+}
+
+// FormatPercent returns a CellFormatter that shows a fraction as a percentage
+// with the given number of decimal places, such as "12.5%" for 0.125 with one
+// decimal place.  Data that isn't a number is shown as is.
+func FormatPercent(decimals int) CellFormatter {
+	return func(data any) string {
+		fraction, ok := asNumber(data)
+
+		if !ok {
+			return fmt.Sprint(data)
+		}
+
+		return strconv.FormatFloat(fraction*100, 'f', decimals, 64) + "%"
+	}
+}
+
+// FormatTimestamp returns a CellFormatter that shows a time.Time with the given
+// layout, such as time.RFC3339.  Integers are treated as Unix timestamps in
+// seconds.  Zero times are shown as empty.  Other data is shown as is.
+func FormatTimestamp(layout string) CellFormatter {
+	return func(data any) string {
+		timestamp, ok := asTime(data)
+
+		if !ok {
+			return fmt.Sprint(data)
+		}
+
+		if timestamp.IsZero() {
+			return ""
+		}
+
+		return timestamp.Format(layout)
+	}
+}
+
+// FormatRelativeTime returns a CellFormatter that shows a time.Time relative to
+// the time returned by now, such as "5m ago" or "in 2h".  Only the largest unit
+// is shown.  If now is nil, time.Now is used.  Integers are treated as Unix
+// timestamps in seconds.  Zero times are shown as empty.  Other data is shown
+// as is.
+func FormatRelativeTime(now func() time.Time) CellFormatter {
+	if now == nil {
+		now = time.Now
+	}
+
+	return func(data any) string {
+		timestamp, ok := asTime(data)
+
+		if !ok {
+			return fmt.Sprint(data)
+		}
+
+		if timestamp.IsZero() {
+			return ""
+		}
+
+		diff := now().Sub(timestamp)
+
+		if diff < 0 {
+			return "in " + shortDuration(-diff)
+		}
+
+		if diff < time.Second {
+			return "now"
+		}
+
+		return shortDuration(diff) + " ago"
+	}
+}
+
+// FormatGroupedNumber returns a CellFormatter that shows a number with commas
+// between groups of thousands and the given number of decimal places, such as
+// "1,234,567.89".  Data that isn't a number is shown as is.
+func FormatGroupedNumber(decimals int) CellFormatter {
+	return func(data any) string {
+		number, ok := asNumber(data)
+
+		if !ok {
+			return fmt.Sprint(data)
+		}
+
+		// Integers are kept exact rather than going through a float
+		if intVal, isInt := asInt(data); isInt && decimals == 0 {
+			return groupThousands(strconv.FormatInt(intVal, 10))
+		}
+
+		return groupThousands(strconv.FormatFloat(number, 'f', decimals, 64))
+	}
+}
+
+// asTime returns the data as a time, treating integers as Unix timestamps.
+func asTime(data any) (time.Time, bool) {
+	if styled, isStyled := data.(StyledCell); isStyled {
+		data = styled.Data
+	}
+
+	switch value := data.(type) {
+	case time.Time:
+		return value, true
+
+	case *time.Time:
+		if value == nil {
+			return time.Time{}, true
+		}
+
+		return *value, true
+	}
+
+	if seconds, isInt := asInt(data); isInt {
+		return time.Unix(seconds, 0), true
+	}
+
+	return time.Time{}, false
+}
+
+// shortDuration shows only the largest unit of a duration, such as "3d".
+func shortDuration(duration time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case duration >= day:
+		return fmt.Sprintf("%dd", duration/day)
+
+	case duration >= time.Hour:
+		return fmt.Sprintf("%dh", duration/time.Hour)
+
+	case duration >= time.Minute:
+		return fmt.Sprintf("%dm", duration/time.Minute)
+	}
+
+	return fmt.Sprintf("%ds", duration/time.Second)
+}
+
+// trimDecimals formats the number with at most the given decimal places,
+// without trailing zeros.
+func trimDecimals(number float64, decimals int) string {
+	if number == math.Trunc(number) {
+		return strconv.FormatFloat(number, 'f', 0, 64)
+	}
+
+	formatted := strconv.FormatFloat(number, 'f', decimals, 64)
+
+	if !strings.Contains(formatted, ".") {
+		return formatted
+	}
+
+	return strings.TrimSuffix(strings.TrimRight(formatted, "0"), ".")
+}
+
+// groupThousands adds commas between groups of thousands in a formatted
+// number, such as "-1234.5" to "-1,234.5".
+func groupThousands(number string) string {
+	const groupSize = 3
+
+	sign := ""
+
+	if strings.HasPrefix(number, "-") {
+		sign = "-"
+		number = number[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(number, ".")
+
+	grouped := strings.Builder{}
+
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%groupSize == 0 {
+			grouped.WriteByte(',')
+		}
+
+		grouped.WriteRune(digit)
+	}
+
+	if hasFraction {
+		return sign + grouped.String() + "." + fraction
+	}
+
+	return sign + grouped.String()
+}
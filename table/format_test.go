@@ -0,0 +1,100 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		data     any
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{10 * 1024 * 1024, "10 MiB"},
+		{int64(3) << 40, "3 TiB"},
+		{-2048, "-2 KiB"},
+		{1023.5, "1023.5 B"},
+		{"abc", "abc"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, FormatBytes(test.data), "%v", test.data)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	format := FormatDuration(time.Second)
+
+	assert.Equal(t, "1h2m3s", format(time.Hour+2*time.Minute+3*time.Second+400*time.Millisecond))
+	assert.Equal(t, "1m30s", format(90))
+	assert.Equal(t, "2s", format(1.5))
+	assert.Equal(t, "n/a", format("n/a"))
+	assert.Equal(t, "1.5s", FormatDuration(0)(1500*time.Millisecond))
+}
+
+func TestFormatPercent(t *testing.T) {
+	assert.Equal(t, "12.5%", FormatPercent(1)(0.125))
+	assert.Equal(t, "100%", FormatPercent(0)(1))
+	assert.Equal(t, "x", FormatPercent(0)("x"))
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	format := FormatTimestamp(time.RFC3339)
+	timestamp := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	assert.Equal(t, "2022-03-04T05:06:07Z", format(timestamp))
+	assert.Equal(t, "2022-03-04T05:06:07Z", format(&timestamp))
+	assert.Equal(t, "1970-01-01T00:01:00Z", FormatTimestamp(time.RFC3339)(time.Unix(60, 0).UTC()))
+	assert.Equal(t, "", format(time.Time{}))
+	assert.Equal(t, "soon", format("soon"))
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	format := FormatRelativeTime(func() time.Time { return now })
+
+	assert.Equal(t, "now", format(now))
+	assert.Equal(t, "5m ago", format(now.Add(-5*time.Minute-10*time.Second)))
+	assert.Equal(t, "in 2h", format(now.Add(2*time.Hour+time.Minute)))
+	assert.Equal(t, "3d ago", format(now.Add(-73*time.Hour)))
+	assert.Equal(t, "30s ago", format(now.Unix()-30))
+	assert.Equal(t, "", format(time.Time{}))
+}
+
+func TestFormatGroupedNumber(t *testing.T) {
+	assert.Equal(t, "1,234,567", FormatGroupedNumber(0)(1234567))
+	assert.Equal(t, "-1,234.50", FormatGroupedNumber(2)(-1234.5))
+	assert.Equal(t, "999", FormatGroupedNumber(0)(999))
+	assert.Equal(t, "100,000", FormatGroupedNumber(0)(100000.4))
+	assert.Equal(t, "9,223,372,036,854,775,807", FormatGroupedNumber(0)(int64(9223372036854775807)))
+	assert.Equal(t, "-", FormatGroupedNumber(0)("-"))
+}
+
+func TestCellFormatterKeepsRawSorting(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 5),
+		NewColumn("size", "Size", 9).WithCellFormatter(FormatBytes).WithFormatString("%d!"),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "big", "size": 2 * 1024 * 1024}),
+		NewRow(RowData{"name": "small", "size": 900}),
+		NewRow(RowData{"name": "mid", "size": NewStyledCell(5*1024, defaultHighlightStyle)}),
+		NewRow(RowData{"name": "none"}),
+	}).SortByAsc("size").WithMissingDataIndicator("?").WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━━┳━━━━━━━━━┓
+┃ Name┃     Size┃
+┣━━━━━╋━━━━━━━━━┫
+┃ none┃        ?┃
+┃small┃    900 B┃
+┃  mid┃    5 KiB┃
+┃  big┃    2 MiB┃
+┗━━━━━┻━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
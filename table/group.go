@@ -0,0 +1,317 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// GroupAggregate is a value shown in each group header when grouping rows with
+// WithGroupBy, such as the sum of a column within the group.
+type GroupAggregate struct {
+	// ColumnKey is the column whose values are aggregated.
+	ColumnKey string
+
+	// Label is shown before the value, or the column's title if empty.
+	Label string
+
+	// Func combines the values, such as AggregateSum.
+	Func AggregateFunc
+}
+
+// rowGroupHeader is the information for a synthetic row that starts a group.
+type rowGroupHeader struct {
+	// key identifies the group, which is its value formatted as a string
+	key   string
+	value any
+
+	// rows are the visible rows in the group, even if collapsed, not
+	// including any child rows
+	rows      []Row
+	collapsed bool
+}
+
+// WithGroupBy groups rows by the value of the given column, with a header row
+// for each group that spans the width of the table and shows how many rows
+// are in the group along with any aggregates set by WithGroupAggregates.
+// Groups are shown in the order that they first appear after sorting, so
+// sorting by the grouped column also sorts the groups.  Groups can be
+// collapsed and expanded from the keyboard with the same keys as child rows.
+//
+// Group headers aren't returned by GetVisibleRows, and row indexes in events
+// and functions such as WithHighlightedRow are indexes into GetVisibleRows, so
+// a group header has a row index of -1.  Rows in collapsed groups keep their
+// index, and highlighting one highlights its group header.  Grouping isn't
+// supported by row sources.  Use an empty column key to stop grouping.
+func (m Model) WithGroupBy(columnKey string) Model {
+	m.groupByColumnKey = columnKey
+	m.collapsedGroups = nil
+	m.visibleRowCacheUpdated = false
+	m.clampRowCursor()
+
+	return m
+}
+
+// WithGroupAggregates sets the aggregate values to show in each group header
+// when grouping rows with WithGroupBy.  Each value is displayed the same way
+// as in the summary row, with the column's summary formatter, cell formatter,
+// or format string.
+func (m Model) WithGroupAggregates(aggregates ...GroupAggregate) Model {
+	m.groupAggregates = make([]GroupAggregate, len(aggregates))
+	copy(m.groupAggregates, aggregates)
+
+	return m
+}
+
+// WithGroupHeaderStyle sets the style of the group header rows when grouping
+// rows with WithGroupBy.
+func (m Model) WithGroupHeaderStyle(style lipgloss.Style) Model {
+	m.groupHeaderStyle = style
+
+	return m
+}
+
+// IsGroupHeader returns true if the row is a group header created by
+// WithGroupBy rather than a row of data, such as from HighlightedRow.
+func (r Row) IsGroupHeader() bool {
+	return r.groupHeader != nil
+}
+
+// isGrouped returns true if rows are grouped into displayed groups.
+func (m *Model) isGrouped() bool {
+	return m.groupByColumnKey != "" && m.rowSource == nil
+}
+
+// displayRows returns the rows that are shown, which are the visible rows
+// along with any group headers.
+func (m *Model) displayRows() []Row {
+	rows := m.GetVisibleRows()
+
+	if m.isGrouped() {
+		return m.groupedRowCache
+	}
+
+	return rows
+}
+
+// rowIndex returns the index in the visible rows of the displayed row at the
+// given index, or -1 if it's a group header.
+func (m *Model) rowIndex(displayIndex int) int {
+	if !m.isGrouped() {
+		return displayIndex
+	}
+
+	m.GetVisibleRows()
+
+	if displayIndex < 0 || displayIndex >= len(m.groupedRowIndexes) {
+		return -1
+	}
+
+	return m.groupedRowIndexes[displayIndex]
+}
+
+// displayIndex returns the index in the displayed rows of the visible row at
+// the given index, which is its group header if its group is collapsed.
+func (m *Model) displayIndex(rowIndex int) int {
+	if !m.isGrouped() {
+		return rowIndex
+	}
+
+	m.GetVisibleRows()
+
+	if len(m.groupedDisplayIndexes) == 0 {
+		return 0
+	}
+
+	rowIndex = max(0, min(rowIndex, len(m.groupedDisplayIndexes)-1))
+
+	return m.groupedDisplayIndexes[rowIndex]
+}
+
+// groupRows splits the visible rows into groups, each starting with a header
+// row.  Child rows stay with their top level row.  Also returns the index in
+// the visible rows of each grouped row, which is -1 for headers, and the index
+// in the grouped rows of each visible row.
+func (m *Model) groupRows(rows []Row) (grouped []Row, rowIndexes []int, displayIndexes []int) {
+	headers := []*rowGroupHeader{}
+	headersByKey := map[string]*rowGroupHeader{}
+	groupMembers := map[string][]int{}
+	currentKey := ""
+
+	for i, row := range rows {
+		if row.depth == 0 {
+			value := row.Data[m.groupByColumnKey]
+
+			if styled, isStyled := value.(StyledCell); isStyled {
+				value = styled.Data
+			}
+
+			currentKey = fmt.Sprint(value)
+
+			header, exists := headersByKey[currentKey]
+
+			if !exists {
+				header = &rowGroupHeader{
+					key:       currentKey,
+					value:     value,
+					collapsed: m.collapsedGroups[currentKey],
+				}
+
+				headers = append(headers, header)
+				headersByKey[currentKey] = header
+			}
+
+			header.rows = append(header.rows, row)
+		}
+
+		groupMembers[currentKey] = append(groupMembers[currentKey], i)
+	}
+
+	grouped = make([]Row, 0, len(rows)+len(headers))
+	rowIndexes = make([]int, 0, len(rows)+len(headers))
+	displayIndexes = make([]int, len(rows))
+
+	for _, header := range headers {
+		headerIndex := len(grouped)
+
+		grouped = append(grouped, Row{
+			groupHeader: header,
+			depth:       -1,
+		})
+		rowIndexes = append(rowIndexes, -1)
+
+		for _, rowIndex := range groupMembers[header.key] {
+			if header.collapsed {
+				displayIndexes[rowIndex] = headerIndex
+
+				continue
+			}
+
+			displayIndexes[rowIndex] = len(grouped)
+			grouped = append(grouped, rows[rowIndex])
+			rowIndexes = append(rowIndexes, rowIndex)
+		}
+	}
+
+	return grouped, rowIndexes, displayIndexes
+}
+
+// setGroupCollapsed collapses or expands the group with the given key.
+func (m *Model) setGroupCollapsed(key string, collapsed bool) {
+	if m.collapsedGroups[key] == collapsed {
+		return
+	}
+
+	// Copy to avoid changing the state of other copies of the model
+	collapsedGroups := make(map[string]bool, len(m.collapsedGroups)+1)
+
+	for k, v := range m.collapsedGroups {
+		collapsedGroups[k] = v
+	}
+
+	if collapsed {
+		collapsedGroups[key] = true
+	} else {
+		delete(collapsedGroups, key)
+	}
+
+	m.collapsedGroups = collapsedGroups
+	m.visibleRowCacheUpdated = false
+	m.clampRowCursor()
+
+	m.appendUserEvent(UserEventRowExpandToggled{
+		RowIndex:   m.rowIndex(m.rowCursorIndex),
+		IsExpanded: !collapsed,
+	})
+}
+
+// groupHeaderText returns the text shown in a group header.  The group's value
+// is formatted like the group column's cells, and aggregates like the summary
+// row for their column.
+func (m Model) groupHeaderText(header *rowGroupHeader) string {
+	glyph := treeExpandedGlyph
+
+	if header.collapsed {
+		glyph = treeCollapsedGlyph
+	}
+
+	groupColumn, exists := m.columnWithKey(m.groupByColumnKey)
+
+	if !exists {
+		groupColumn = NewColumn(m.groupByColumnKey, m.groupByColumnKey, 0)
+	}
+
+	formatData := groupColumn.formatData
+	value := header.value
+
+	if value == nil {
+		// Matches how cells with missing data are shown
+		formatData = func(data any) string {
+			return fmt.Sprintf("%v", data)
+		}
+
+		value = m.missingDataIndicator
+
+		if value == nil {
+			value = ""
+		}
+	}
+
+	if styled, isStyled := value.(StyledCell); isStyled {
+		value = styled.Data
+	}
+
+	parts := []string{fmt.Sprintf("%s %s: %s (%d)", glyph, groupColumn.title, formatData(value), len(header.rows))}
+
+	for _, aggregate := range m.groupAggregates {
+		if aggregate.Func == nil {
+			continue
+		}
+
+		column, exists := m.columnWithKey(aggregate.ColumnKey)
+
+		if !exists {
+			column = NewColumn(aggregate.ColumnKey, aggregate.ColumnKey, 0)
+		}
+
+		label := aggregate.Label
+
+		if label == "" {
+			label = column.title
+		}
+
+		result := aggregate.Func(columnValues(header.rows, aggregate.ColumnKey))
+
+		parts = append(parts, fmt.Sprintf("%s: %s", label, column.formatSummary(result)))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+// columnWithKey returns the column with the given key, if there is one.
+func (m Model) columnWithKey(key string) (Column, bool) {
+	for _, column := range m.columns {
+		if column.key == key {
+			return column, true
+		}
+	}
+
+	return Column{}, false
+}
+
+// renderGroupHeader renders a group header row across the full width of the
+// table.
+func (m Model) renderGroupHeader(header *rowGroupHeader, highlighted bool, last bool) string {
+	width := m.fullRowWidth()
+
+	style := m.groupHeaderStyle.Copy()
+
+	if m.focused && highlighted {
+		style = m.highlightStyle.Copy().Inherit(style)
+	}
+
+	style = style.Inherit(m.baseStyle).Inherit(m.fullRowBorderStyle(last)).Align(lipgloss.Left).Width(width)
+
+	return style.Render(limitStr(m.groupHeaderText(header), width))
+}
@@ -0,0 +1,251 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func genGroupModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 12).WithFiltered(true),
+		NewColumn("status", "Status", 10),
+		NewColumn("cpu", "CPU", 6),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "web-1", "status": "Running", "cpu": 10}).WithID("web-1"),
+		NewRow(RowData{"name": "db-1", "status": "Pending", "cpu": 1}).WithID("db-1"),
+		NewRow(RowData{"name": "web-2", "status": NewStyledCell("Running", defaultHighlightStyle), "cpu": 5}).WithID("web-2"),
+		NewRow(RowData{"name": "cache", "status": "Running", "cpu": "x"}).WithID("cache"),
+	}).WithGroupBy("status").Focused(true)
+}
+
+// displayNames shows the displayed rows with group headers in brackets
+func displayNames(model Model) []string {
+	names := []string{}
+
+	for _, row := range model.displayRows() {
+		if row.IsGroupHeader() {
+			names = append(names, "["+row.groupHeader.key+"]")
+		} else {
+			names = append(names, row.Data["name"].(string))
+		}
+	}
+
+	return names
+}
+
+func TestGroupByGroupsInOrderOfAppearance(t *testing.T) {
+	model := genGroupModel()
+
+	assert.Equal(t, []string{"[Running]", "web-1", "web-2", "cache", "[Pending]", "db-1"}, displayNames(model))
+
+	// Group headers aren't data
+	assert.Len(t, model.GetVisibleRows(), 4)
+	assert.Equal(t, 6, model.visibleRowCount())
+	assert.True(t, model.HighlightedRow().IsGroupHeader())
+
+	model = model.WithGroupBy("")
+
+	assert.Equal(t, []string{"web-1", "db-1", "web-2", "cache"}, displayNames(model))
+}
+
+func TestGroupByWithSortingAndFiltering(t *testing.T) {
+	model := genGroupModel().SortByAsc("status").ThenSortByDesc("name")
+
+	assert.Equal(t, []string{"[Pending]", "db-1", "[Running]", "web-2", "web-1", "cache"}, displayNames(model))
+
+	model = model.Filtered(true).WithFilterInputValue("web")
+
+	assert.Equal(t, []string{"[Running]", "web-2", "web-1"}, displayNames(model))
+}
+
+func TestGroupByCollapseKeys(t *testing.T) {
+	model := genGroupModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+
+	assert.Equal(t, []string{"[Running]", "[Pending]", "db-1"}, displayNames(model))
+	assert.Equal(t, []UserEvent{UserEventRowExpandToggled{RowIndex: -1, IsExpanded: false}}, model.GetLastUpdateUserEvents())
+
+	// Collapsing a row in a group moves to the group header
+	model = model.WithHighlightedRow(1)
+
+	assert.Equal(t, "db-1", model.HighlightedRow().Data["name"])
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+
+	assert.Equal(t, -1, model.GetHighlightedRowIndex())
+	assert.Equal(t, "Pending", model.HighlightedRow().groupHeader.key)
+
+	// Highlighting a row in a collapsed group highlights its header
+	model = model.WithHighlightedRow(0)
+
+	assert.Equal(t, "Running", model.HighlightedRow().groupHeader.key)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+
+	assert.Equal(t, []string{"[Running]", "web-1", "web-2", "cache", "[Pending]", "db-1"}, displayNames(model))
+}
+
+func TestGroupByHeaderIgnoresSelectAndEdit(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 8).WithEditable(true),
+		NewColumn("status", "Status", 8),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a", "status": "x"}),
+	}).WithGroupBy("status").SelectableRows(true).WithCellCursor(true).Focused(true)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Empty(t, model.SelectedRows())
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	cmd := model.startCellEdit()
+
+	assert.Nil(t, cmd)
+	assert.Nil(t, model.cellEditor)
+}
+
+func TestGroupByHeaderText(t *testing.T) {
+	model := genGroupModel().WithGroupAggregates(
+		GroupAggregate{ColumnKey: "cpu", Func: AggregateSum},
+		GroupAggregate{ColumnKey: "cpu", Label: "avg", Func: AggregateAvg},
+		GroupAggregate{ColumnKey: "name", Label: "named", Func: AggregateCount},
+	)
+
+	rows := model.displayRows()
+
+	assert.Equal(t, "▾ Status: Running (3)  CPU: 15  avg: 7.5  named: 3", model.groupHeaderText(rows[0].groupHeader))
+	assert.Equal(t, "▾ Status: Pending (1)  CPU: 1  avg: 1  named: 1", model.groupHeaderText(rows[4].groupHeader))
+}
+
+func TestGroupByHeaderTextUsesColumnFormatters(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Name", 12),
+		NewColumn("size", "Size", 10).WithCellFormatter(FormatBytes),
+		NewColumn("ratio", "Ratio", 6).WithFormatString("%.1f%%"),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a", "size": 1024, "ratio": 50.0}),
+		NewRow(RowData{"name": "b", "size": 1024, "ratio": 50.0}),
+		NewRow(RowData{"name": "c", "size": 2048, "ratio": 12.5}),
+	}).WithGroupBy("ratio").WithGroupAggregates(
+		GroupAggregate{ColumnKey: "size", Func: AggregateSum},
+		GroupAggregate{ColumnKey: "ratio", Label: "avg", Func: AggregateAvg},
+	)
+
+	rows := model.displayRows()
+
+	assert.Equal(t, "▾ Ratio: 50.0% (2)  Size: 2 KiB  avg: 50.0%", model.groupHeaderText(rows[0].groupHeader))
+	assert.Equal(t, "▾ Ratio: 12.5% (1)  Size: 2 KiB  avg: 12.5%", model.groupHeaderText(rows[3].groupHeader))
+
+	model = model.WithColumns([]Column{
+		NewColumn("name", "Name", 12),
+		NewColumn("size", "Size", 10).WithCellFormatter(FormatBytes).WithSummaryFormatter(func(data any) string {
+			return fmt.Sprintf("%v bytes", data)
+		}),
+		NewColumn("ratio", "Ratio", 6).WithFormatString("%.1f%%"),
+	})
+
+	rows = model.displayRows()
+
+	assert.Equal(t, "▾ Ratio: 50.0% (2)  Size: 2048 bytes  avg: 50.0%", model.groupHeaderText(rows[0].groupHeader))
+}
+
+func TestGroupByView(t *testing.T) {
+	model := genGroupModel().
+		WithGroupAggregates(GroupAggregate{ColumnKey: "cpu", Label: "sum", Func: AggregateSum}).
+		WithGroupHeaderStyle(lipgloss.NewStyle()).
+		WithFooterVisibility(false).
+		Focused(false)
+
+	model, _ = model.Update(nil)
+	model = model.WithHighlightedRow(4)
+	model.setGroupCollapsed("Pending", true)
+
+	const expectedTable = `┏━━━━━━━━━━━━┳━━━━━━━━━━┳━━━━━━┓
+┃        Name┃    Status┃   CPU┃
+┣━━━━━━━━━━━━╋━━━━━━━━━━╋━━━━━━┫
+┃▾ Status: Running (3)  sum: 15┃
+┃       web-1┃   Running┃    10┃
+┃       web-2┃   Running┃     5┃
+┃       cache┃   Running┃     x┃
+┃▸ Status: Pending (1)  sum: 1 ┃
+┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestGroupByKeepsHighlightThroughChanges(t *testing.T) {
+	model := genGroupModel().HighlightRowByID("db-1")
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+
+	model = model.UpsertRows(NewRow(RowData{"name": "db-2", "status": "Pending", "cpu": 2}).WithID("db-2"))
+
+	assert.Equal(t, []string{"[Running]", "web-1", "web-2", "cache", "[Pending]", "db-1", "db-2"}, displayNames(model))
+	assert.Equal(t, "db-1", model.HighlightedRow().Data["name"])
+
+	// Groups are in order of first appearance, which changes here
+	model = model.DeleteRows("web-1", "web-2")
+
+	assert.Equal(t, []string{"[Pending]", "db-1", "db-2", "[Running]", "cache"}, displayNames(model))
+	assert.Equal(t, "db-1", model.HighlightedRow().Data["name"])
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+}
+
+func TestGroupByKeepsHighlightThroughWithRows(t *testing.T) {
+	model := genGroupModel().HighlightRowByID("db-1")
+
+	assert.Equal(t, 5, model.rowCursorIndex, "The last displayed row should be highlighted")
+
+	model = model.WithRows([]Row{
+		NewRow(RowData{"name": "web-1", "status": "Running", "cpu": 10}).WithID("web-1"),
+		NewRow(RowData{"name": "db-1", "status": "Pending", "cpu": 1}).WithID("db-1"),
+		NewRow(RowData{"name": "web-2", "status": "Running", "cpu": 5}).WithID("web-2"),
+		NewRow(RowData{"name": "cache", "status": "Running", "cpu": "x"}).WithID("cache"),
+	})
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Equal(t, "db-1", model.HighlightedRow().Data["name"])
+}
+
+func TestGroupByIndexesAreVisibleRowIndexes(t *testing.T) {
+	model := genGroupModel()
+
+	assert.Equal(t, -1, model.GetHighlightedRowIndex(), "Group headers have no row index")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Equal(t, []UserEvent{UserEventHighlightedIndexChanged{
+		PreviousRowIndex: 0,
+		SelectedRowIndex: 2,
+	}}, model.GetLastUpdateUserEvents())
+	assert.Equal(t, model.GetVisibleRows()[2], model.HighlightedRow())
+
+	model = model.WithHighlightedRow(1)
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Equal(t, "db-1", model.HighlightedRow().Data["name"])
+}
+
+func TestAggregates(t *testing.T) {
+	values := []any{1, 2.5, "x", NewStyledCell(3, defaultHighlightStyle)}
+
+	assert.Equal(t, 4, AggregateCount(values))
+	assert.Equal(t, 6.5, AggregateSum(values))
+	assert.InDelta(t, 6.5/3, AggregateAvg(values), 0.0001)
+	assert.Nil(t, AggregateAvg([]any{"x"}))
+	assert.Equal(t, 0.0, AggregateSum(nil))
+}
+
+func TestFormatAggregate(t *testing.T) {
+	assert.Equal(t, "12", formatAggregate(12.0))
+	assert.Equal(t, "4.33", formatAggregate(13.0/3))
+	assert.Equal(t, "4.5", formatAggregate(4.5))
+	assert.Equal(t, "", formatAggregate(nil))
+	assert.Equal(t, "7", formatAggregate(7))
+}
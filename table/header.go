@@ -1,6 +1,9 @@
 package table
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
 
 // This is long and could use some refactoring in the future, but unsure of how
 // to pick it apart right now.
@@ -16,7 +19,7 @@ func (m Model) renderHeaders() string {
 	renderHeader := func(column Column, borderStyle lipgloss.Style) string {
 		borderStyle = borderStyle.Inherit(column.style).Inherit(m.baseStyle)
 
-		headerSection := limitStr(column.title, column.width)
+		headerSection := m.renderHeaderTitle(column)
 
 		return borderStyle.Render(headerSection)
 	}
@@ -51,6 +54,10 @@ func (m Model) renderHeaders() string {
 			borderStyle = headerStyles.right.Copy()
 		}
 
+		if m.isColumnCursorShown() && columnIndex == m.columnCursorIndex {
+			borderStyle = m.headerHighlightStyle.Copy().Inherit(borderStyle)
+		}
+
 		rendered := renderHeader(column, borderStyle)
 
 		if m.maxTotalWidth != 0 {
@@ -91,3 +98,21 @@ func (m Model) renderHeaders() string {
 
 	return headerBlock
 }
+
+// renderHeaderTitle returns the title of the column limited to the column's
+// width, always keeping any sort indicator visible.
+func (m Model) renderHeaderTitle(column Column) string {
+	indicator := m.sortIndicator(column.key)
+
+	if indicator == "" {
+		return limitStr(column.title, column.width)
+	}
+
+	titleWidth := column.width - ansi.PrintableRuneWidth(indicator)
+
+	if titleWidth <= 0 {
+		return limitStr(indicator, column.width)
+	}
+
+	return limitStr(column.title, titleWidth) + indicator
+}
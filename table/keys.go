@@ -9,6 +9,17 @@ type KeyMap struct {
 
 	RowSelectToggle key.Binding
 
+	// RowExpand shows the children of the highlighted row.
+	RowExpand key.Binding
+
+	// RowCollapse hides the children of the highlighted row, or moves to its
+	// parent row if it's not expanded.
+	RowCollapse key.Binding
+
+	// RowDetailToggle shows or hides the detail panel of the highlighted row
+	// when a detail function is set with WithRowDetail.
+	RowDetailToggle key.Binding
+
 	PageDown  key.Binding
 	PageUp    key.Binding
 	PageFirst key.Binding
@@ -28,6 +39,88 @@ type KeyMap struct {
 
 	// ScrollLeft will move one column to the left when overflow occurs.
 	ScrollLeft key.Binding
+
+	// CellLeft moves the highlighted cell one column to the left when the cell
+	// cursor is enabled.
+	CellLeft key.Binding
+
+	// CellRight moves the highlighted cell one column to the right when the cell
+	// cursor is enabled.
+	CellRight key.Binding
+
+	// CellEditStart starts editing the highlighted cell, if its column is
+	// editable.
+	CellEditStart key.Binding
+
+	// CellEditCommit commits the current cell edit.
+	CellEditCommit key.Binding
+
+	// CellEditCancel cancels the current cell edit, leaving the cell unchanged.
+	CellEditCancel key.Binding
+
+	// SortColumnNext moves the column chosen for interactive sorting,
+	// resizing, or arranging to the right.
+	SortColumnNext key.Binding
+
+	// SortColumnPrev moves the column chosen for interactive sorting,
+	// resizing, or arranging to the left.
+	SortColumnPrev key.Binding
+
+	// SortCycle cycles the chosen column between ascending, descending, and no
+	// sorting, replacing any other sorting.
+	SortCycle key.Binding
+
+	// SortCycleAdd cycles the chosen column between ascending, descending, and
+	// no sorting, keeping any other sorted columns.
+	SortCycleAdd key.Binding
+
+	// ColumnWiden makes the chosen column wider when resizing is enabled with
+	// WithColumnResizing.
+	ColumnWiden key.Binding
+
+	// ColumnNarrow makes the chosen column narrower when resizing is enabled
+	// with WithColumnResizing.
+	ColumnNarrow key.Binding
+
+	// ColumnMoveLeft moves the chosen column to the left, or up in the column
+	// chooser, when arranging is enabled with WithColumnArranging.
+	ColumnMoveLeft key.Binding
+
+	// ColumnMoveRight moves the chosen column to the right, or down in the
+	// column chooser, when arranging is enabled with WithColumnArranging.
+	ColumnMoveRight key.Binding
+
+	// ColumnHide hides the chosen column when arranging is enabled with
+	// WithColumnArranging.
+	ColumnHide key.Binding
+
+	// ColumnChooser opens the column chooser when arranging is enabled with
+	// WithColumnArranging.
+	ColumnChooser key.Binding
+
+	// ColumnChooserToggle shows or hides the highlighted column in the column
+	// chooser.
+	ColumnChooserToggle key.Binding
+
+	// ColumnChooserClose closes the column chooser.
+	ColumnChooserClose key.Binding
+
+	// Export writes the visible rows to the target set by WithExportTarget.
+	// It's only matched while a target is set, and isn't part of the default
+	// help.
+	Export key.Binding
+
+	// CopyRow copies the highlighted row to the clipboard when enabled with
+	// WithClipboardCopy.
+	CopyRow key.Binding
+
+	// CopyCell copies the highlighted cell to the clipboard when enabled with
+	// WithClipboardCopy and the cell cursor is enabled.
+	CopyCell key.Binding
+
+	// CopySelected copies the selected rows to the clipboard when enabled
+	// with WithClipboardCopy.
+	CopySelected key.Binding
 }
 
 // DefaultKeyMap returns a set of sensible defaults for controlling a focused table with help text.
@@ -45,6 +138,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys(" ", "enter"),
 			key.WithHelp("<space>/enter", "select row"),
 		),
+		RowExpand: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "expand row"),
+		),
+		RowCollapse: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "collapse row"),
+		),
+		RowDetailToggle: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "row details"),
+		),
 		PageDown: key.NewBinding(
 			key.WithKeys("right", "l", "pgdown"),
 			key.WithHelp("→/h/page down", "next page"),
@@ -81,6 +186,90 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("shift+left"),
 			key.WithHelp("shift+←", "scroll left"),
 		),
+		CellLeft: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous cell"),
+		),
+		CellRight: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next cell"),
+		),
+		CellEditStart: key.NewBinding(
+			key.WithKeys("e", "f2"),
+			key.WithHelp("e/f2", "edit cell"),
+		),
+		CellEditCommit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save edit"),
+		),
+		CellEditCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel edit"),
+		),
+		SortColumnNext: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "next sort column"),
+		),
+		SortColumnPrev: key.NewBinding(
+			key.WithKeys(","),
+			key.WithHelp(",", "previous sort column"),
+		),
+		SortCycle: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+		SortCycleAdd: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "add sort"),
+		),
+		ColumnWiden: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen column"),
+		),
+		ColumnNarrow: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow column"),
+		),
+		ColumnMoveLeft: key.NewBinding(
+			key.WithKeys("alt+left", "alt+up"),
+			key.WithHelp("alt+←", "move column left"),
+		),
+		ColumnMoveRight: key.NewBinding(
+			key.WithKeys("alt+right", "alt+down"),
+			key.WithHelp("alt+→", "move column right"),
+		),
+		ColumnHide: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "hide column"),
+		),
+		ColumnChooser: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "choose columns"),
+		),
+		ColumnChooserToggle: key.NewBinding(
+			key.WithKeys(" ", "enter"),
+			key.WithHelp("space", "show/hide column"),
+		),
+		ColumnChooserClose: key.NewBinding(
+			key.WithKeys("esc", "C"),
+			key.WithHelp("esc", "close"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "export"),
+		),
+		CopyRow: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy row"),
+		),
+		CopyCell: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy cell"),
+		),
+		CopySelected: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy selected"),
+		),
 	}
 }
 
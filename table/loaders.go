@@ -0,0 +1,475 @@
+package table
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/reflow/ansi"
+)
+
+// loaderMaxColumnWidth is the widest that a loader will make a column to fit
+// its content, so that one long value doesn't take over the table.
+const loaderMaxColumnWidth = 40
+
+// ErrLoaderNotStructs is returned by NewFromStructs when the items aren't
+// structs or pointers to structs.
+var ErrLoaderNotStructs = errors.New("items must be structs or pointers to structs")
+
+// ErrLoaderDuplicateColumn is returned by NewFromCSV when two columns in the
+// header row have the same title, since titles are used as column keys.
+var ErrLoaderDuplicateColumn = errors.New("duplicate column title")
+
+// loadedKind is the type that the values of a loaded column are stored as,
+// ordered so that a column takes the last kind that any of its values need.
+type loadedKind int
+
+const (
+	loadedKindInt loadedKind = iota
+	loadedKindFloat
+	loadedKindString
+)
+
+// NewFromCSV creates a table from CSV data, where the first record is a header
+// row with the title of each column, which is also used as its key, so titles
+// must be unique.  If every value in a column is a whole number, the column is
+// stored as int, or else if every value is a number, it's stored as float64,
+// so that it sorts as numbers.  Otherwise the column is stored as strings.
+// Only plain decimal numbers count, so values such as "007", "+1", and "1e3"
+// are kept as they're written.  Empty values are left out as missing data.
+// Columns are wide enough to fit their content up to a limit, and are all
+// filterable.
+func NewFromCSV(reader io.Reader) (Model, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return New(nil), nil
+	}
+
+	header := records[0]
+	seenTitles := make(map[string]bool, len(header))
+
+	for _, title := range header {
+		if seenTitles[title] {
+			return Model{}, fmt.Errorf("%w in CSV: %q", ErrLoaderDuplicateColumn, title)
+		}
+
+		seenTitles[title] = true
+	}
+
+	kinds := make([]loadedKind, len(header))
+
+	for i := range header {
+		kinds[i] = loadedColumnKind(records[1:], i)
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+
+	for _, record := range records[1:] {
+		data := RowData{}
+
+		for i, value := range record {
+			if i < len(header) && value != "" {
+				data[header[i]] = parseLoadedValue(value, kinds[i])
+			}
+		}
+
+		rows = append(rows, NewRow(data))
+	}
+
+	columns := make([]Column, len(header))
+
+	for i, title := range header {
+		columns[i] = NewColumn(title, title, 0).WithFiltered(true).withLoadedWidth(rows)
+	}
+
+	return New(columns).WithRows(rows), nil
+}
+
+// NewFromJSON creates a table from a JSON array of objects, with a column for
+// each key in the order that keys first appear.  Numbers are stored as int if
+// they're whole numbers or float64 otherwise, and nested objects and arrays
+// are stored as maps and slices.  Columns are wide enough to fit their content
+// up to a limit, and are all filterable.
+func NewFromJSON(reader io.Reader) (Model, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	if err := expectJSONDelim(decoder, '['); err != nil {
+		return Model{}, err
+	}
+
+	keys := []string{}
+	seenKeys := map[string]bool{}
+	rows := []Row{}
+
+	for decoder.More() {
+		data, objectKeys, err := decodeJSONObject(decoder)
+
+		if err != nil {
+			return Model{}, err
+		}
+
+		for _, key := range objectKeys {
+			if !seenKeys[key] {
+				seenKeys[key] = true
+				keys = append(keys, key)
+			}
+		}
+
+		rows = append(rows, NewRow(data))
+	}
+
+	if err := expectJSONDelim(decoder, ']'); err != nil {
+		return Model{}, err
+	}
+
+	columns := make([]Column, len(keys))
+
+	for i, key := range keys {
+		columns[i] = NewColumn(key, key, 0).WithFiltered(true).withLoadedWidth(rows)
+	}
+
+	return New(columns).WithRows(rows), nil
+}
+
+// NewFromStructs creates a table from a list of structs or pointers to
+// structs, with a column for each exported field.  Columns are set with a
+// struct tag named "table" with comma separated options, where the first
+// option is the column key, or "-" to leave the field out:
+//
+//	Name  string  `table:"name,title=Full Name,width=20,filterable"`
+//	Score float64 `table:"score,format=%.1f"`
+//	Notes string  `table:"notes,flex=2"`
+//
+// The key defaults to the field name, and the title defaults to the key.  If
+// no width or flex factor is set, the column is wide enough to fit its
+// content up to a limit.  Nil pointers are shown as rows without any data.
+func NewFromStructs[T any](items []T) (Model, error) {
+	itemType := reflect.TypeOf((*T)(nil)).Elem()
+
+	if itemType.Kind() == reflect.Pointer {
+		itemType = itemType.Elem()
+	}
+
+	if itemType.Kind() != reflect.Struct {
+		return Model{}, ErrLoaderNotStructs
+	}
+
+	fields, err := structColumnFields(itemType)
+
+	if err != nil {
+		return Model{}, err
+	}
+
+	rows := make([]Row, 0, len(items))
+
+	for _, item := range items {
+		value := reflect.ValueOf(item)
+
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				rows = append(rows, NewRow(nil))
+
+				continue
+			}
+
+			value = value.Elem()
+		}
+
+		data := RowData{}
+
+		for _, field := range fields {
+			data[field.key] = value.Field(field.index).Interface()
+		}
+
+		rows = append(rows, NewRow(data))
+	}
+
+	columns := make([]Column, len(fields))
+
+	for i, field := range fields {
+		var column Column
+
+		if field.flexFactor > 0 {
+			column = NewFlexColumn(field.key, field.title, field.flexFactor)
+		} else {
+			column = NewColumn(field.key, field.title, field.width)
+		}
+
+		column = column.WithFormatString(field.format).WithFiltered(field.filterable)
+
+		if field.flexFactor == 0 && field.width == 0 {
+			column = column.withLoadedWidth(rows)
+		}
+
+		columns[i] = column
+	}
+
+	return New(columns).WithRows(rows), nil
+}
+
+// structColumnField is a struct field shown as a column.
+type structColumnField struct {
+	index      int
+	key        string
+	title      string
+	width      int
+	flexFactor int
+	format     string
+	filterable bool
+}
+
+// structColumnFields returns the fields of the struct type to show as columns,
+// as set by their struct tags.
+func structColumnFields(structType reflect.Type) ([]structColumnField, error) {
+	fields := []structColumnField{}
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		options := strings.Split(structField.Tag.Get("table"), ",")
+
+		if options[0] == "-" {
+			continue
+		}
+
+		field := structColumnField{
+			index: i,
+			key:   options[0],
+		}
+
+		if field.key == "" {
+			field.key = structField.Name
+		}
+
+		for _, option := range options[1:] {
+			name, value, _ := strings.Cut(option, "=")
+
+			if err := field.setOption(strings.TrimSpace(name), value); err != nil {
+				return nil, fmt.Errorf("invalid table tag on field %s: %w", structField.Name, err)
+			}
+		}
+
+		if field.title == "" {
+			field.title = field.key
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// setOption sets a single option from a struct tag.
+func (f *structColumnField) setOption(name, value string) error {
+	var err error
+
+	switch name {
+	case "title":
+		f.title = value
+
+	case "width":
+		f.width, err = strconv.Atoi(value)
+
+	case "flex":
+		f.flexFactor, err = strconv.Atoi(value)
+
+	case "format":
+		f.format = value
+
+	case "filterable":
+		f.filterable = true
+
+	default:
+		return fmt.Errorf("unknown option %q", name)
+	}
+
+	return err
+}
+
+// withLoadedWidth returns the column with a width that fits its title and its
+// formatted data in each row, up to a limit.
+func (c Column) withLoadedWidth(rows []Row) Column {
+	width := ansi.PrintableRuneWidth(c.title)
+
+	for _, row := range rows {
+		if data, exists := row.Data[c.key]; exists {
+			width = max(width, ansi.PrintableRuneWidth(c.formatData(data)))
+		}
+	}
+
+	c.width = min(max(width, 1), loaderMaxColumnWidth)
+
+	return c
+}
+
+// loadedColumnKind returns the kind that every non-empty value in the column
+// at the given index of the records can be stored as.
+func loadedColumnKind(records [][]string, index int) loadedKind {
+	kind := loadedKindInt
+
+	for _, record := range records {
+		if index >= len(record) || record[index] == "" {
+			continue
+		}
+
+		if valueKind := loadedValueKind(record[index]); valueKind > kind {
+			kind = valueKind
+		}
+	}
+
+	return kind
+}
+
+// loadedValueKind returns the kind that the text can be stored as without
+// changing how it's shown.  Only plain decimal numbers without leading zeros
+// or a plus sign are numbers, since values such as "007" and "1e3" are more
+// likely to be codes than numbers.
+func loadedValueKind(value string) loadedKind {
+	integer, fraction, hasFraction := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+
+	if !isDigits(integer) || integer[0] == '0' && len(integer) > 1 || hasFraction && !isDigits(fraction) {
+		return loadedKindString
+	}
+
+	if hasFraction {
+		return loadedKindFloat
+	}
+
+	if _, err := strconv.Atoi(value); err != nil {
+		return loadedKindFloat
+	}
+
+	return loadedKindInt
+}
+
+// isDigits returns true if the text is one or more ASCII digits.
+func isDigits(text string) bool {
+	if text == "" {
+		return false
+	}
+
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseLoadedValue converts text to the given kind, which it must be valid
+// for.
+func parseLoadedValue(value string, kind loadedKind) any {
+	switch kind {
+	case loadedKindInt:
+		intVal, _ := strconv.Atoi(value)
+
+		return intVal
+
+	case loadedKindFloat:
+		floatVal, _ := strconv.ParseFloat(value, 64)
+
+		return floatVal
+	}
+
+	return value
+}
+
+// expectJSONDelim reads the next token and checks that it's the delimiter.
+func expectJSONDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+
+	if err != nil {
+		return fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %q in JSON but found %v", delim, token)
+	}
+
+	return nil
+}
+
+// decodeJSONObject reads an object, returning its data and the keys in the
+// order they appear.
+func decodeJSONObject(decoder *json.Decoder) (RowData, []string, error) {
+	if err := expectJSONDelim(decoder, '{'); err != nil {
+		return nil, nil, err
+	}
+
+	data := RowData{}
+	keys := []string{}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read JSON: %w", err)
+		}
+
+		key, isString := token.(string)
+
+		if !isString {
+			return nil, nil, fmt.Errorf("expected an object key in JSON but found %v", token)
+		}
+
+		var value any
+
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("failed to read JSON: %w", err)
+		}
+
+		if _, exists := data[key]; !exists {
+			keys = append(keys, key)
+		}
+
+		data[key] = convertJSONNumbers(value)
+	}
+
+	if err := expectJSONDelim(decoder, '}'); err != nil {
+		return nil, nil, err
+	}
+
+	return data, keys, nil
+}
+
+// convertJSONNumbers replaces json.Number values with int or float64.
+func convertJSONNumbers(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if intVal, err := strconv.Atoi(value.String()); err == nil {
+			return intVal
+		}
+
+		floatVal, _ := value.Float64()
+
+		return floatVal
+
+	case map[string]any:
+		for key, nested := range value {
+			value[key] = convertJSONNumbers(nested)
+		}
+
+	case []any:
+		for i, nested := range value {
+			value[i] = convertJSONNumbers(nested)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,158 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func columnSummaries(model Model) []string {
+	summaries := []string{}
+
+	for _, column := range model.columns {
+		summary := column.key + ":" + column.title
+
+		if column.filterable {
+			summary += ":filterable"
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+func TestNewFromCSV(t *testing.T) {
+	model, err := NewFromCSV(strings.NewReader("name,count,ratio\nalpha,3,0.5\nb,12,x\nc,,nan\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name:name:filterable", "count:count:filterable", "ratio:ratio:filterable"}, columnSummaries(model))
+	assert.Equal(t, []int{5, 5, 5}, []int{model.columns[0].width, model.columns[1].width, model.columns[2].width})
+
+	rows := model.GetVisibleRows()
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, RowData{"name": "alpha", "count": 3, "ratio": "0.5"}, rows[0].Data)
+	assert.Equal(t, RowData{"name": "c", "ratio": "nan"}, rows[2].Data)
+
+	// Numbers sort as numbers
+	model = model.SortByDesc("count")
+
+	assert.Equal(t, "b", model.GetVisibleRows()[0].Data["name"])
+}
+
+func TestNewFromCSVColumnTypes(t *testing.T) {
+	model, err := NewFromCSV(strings.NewReader(
+		"int,float,zip,signed,exp\n1,1.5,02134,+1,1e3\n-2,3,10001,2,2\n",
+	))
+
+	assert.NoError(t, err)
+
+	rows := model.GetVisibleRows()
+
+	assert.Equal(t, RowData{"int": 1, "float": 1.5, "zip": "02134", "signed": "+1", "exp": "1e3"}, rows[0].Data)
+	assert.Equal(t, RowData{"int": -2, "float": 3.0, "zip": "10001", "signed": "2", "exp": "2"}, rows[1].Data)
+}
+
+func TestNewFromCSVDuplicateTitles(t *testing.T) {
+	_, err := NewFromCSV(strings.NewReader("id,name,id\n1,a,2\n"))
+
+	assert.ErrorIs(t, err, ErrLoaderDuplicateColumn)
+}
+
+func TestNewFromCSVEmptyAndInvalid(t *testing.T) {
+	model, err := NewFromCSV(strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Empty(t, model.columns)
+
+	_, err = NewFromCSV(strings.NewReader("a,b\n1,2,3\n"))
+
+	assert.Error(t, err)
+}
+
+func TestNewFromJSON(t *testing.T) {
+	model, err := NewFromJSON(strings.NewReader(`[
+		{"id": 1, "name": "a very long name that goes on", "score": 1.5},
+		{"id": 2, "tags": ["x", 3], "name": "b"},
+		{"id": 3, "meta": {"n": 4}}
+	]`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id:id:filterable", "name:name:filterable", "score:score:filterable", "tags:tags:filterable", "meta:meta:filterable"}, columnSummaries(model))
+	assert.Equal(t, 29, model.columns[1].width)
+
+	rows := model.GetVisibleRows()
+
+	assert.Equal(t, RowData{"id": 1, "name": "a very long name that goes on", "score": 1.5}, rows[0].Data)
+	assert.Equal(t, []any{"x", 3}, rows[1].Data["tags"])
+	assert.Equal(t, map[string]any{"n": 4}, rows[2].Data["meta"])
+}
+
+func TestNewFromJSONInvalid(t *testing.T) {
+	for _, input := range []string{`{"a": 1}`, `[1, 2]`, `[{"a": 1}`, `[{"a": }]`} {
+		_, err := NewFromJSON(strings.NewReader(input))
+
+		assert.Error(t, err, input)
+	}
+}
+
+type loaderPerson struct {
+	Name    string  `table:"name,title=Full Name,filterable"`
+	Score   float64 `table:"score,format=%.1f"`
+	Notes   string  `table:"notes,flex=2"`
+	Age     int     `table:",width=4"`
+	Secret  string  `table:"-"`
+	private string
+}
+
+func TestNewFromStructs(t *testing.T) {
+	model, err := NewFromStructs([]loaderPerson{
+		{Name: "Alice", Score: 12.25, Notes: "n", Age: 30, Secret: "s", private: "p"},
+		{Name: "Bob", Score: 3},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name:Full Name:filterable", "score:score", "notes:notes", "Age:Age"}, columnSummaries(model))
+	assert.Equal(t, 9, model.columns[0].width)
+	assert.Equal(t, 5, model.columns[1].width)
+	assert.Equal(t, 2, model.columns[2].flexFactor)
+	assert.Equal(t, 4, model.columns[3].width)
+	assert.Equal(t, "%.1f", model.columns[1].fmtString)
+	assert.Equal(t, RowData{"name": "Alice", "score": 12.25, "notes": "n", "Age": 30}, model.GetVisibleRows()[0].Data)
+}
+
+func TestNewFromStructsPointers(t *testing.T) {
+	model, err := NewFromStructs([]*loaderPerson{{Name: "A"}, nil})
+
+	assert.NoError(t, err)
+
+	rows := model.GetVisibleRows()
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "A", rows[0].Data["name"])
+	assert.Empty(t, rows[1].Data)
+}
+
+func TestNewFromStructsErrors(t *testing.T) {
+	_, err := NewFromStructs([]int{1})
+
+	assert.ErrorIs(t, err, ErrLoaderNotStructs)
+
+	type badWidth struct {
+		A int `table:"a,width=wide"`
+	}
+
+	_, err = NewFromStructs([]badWidth{})
+
+	assert.Error(t, err)
+
+	type unknownOption struct {
+		A int `table:"a,bold"`
+	}
+
+	_, err = NewFromStructs([]unknownOption{})
+
+	assert.Error(t, err)
+}
@@ -1,6 +1,9 @@
 package table
 
 import (
+	"io"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +16,13 @@ const (
 
 var (
 	defaultHighlightStyle = lipgloss.NewStyle().Background(lipgloss.Color("#334"))
+
+	defaultHighlightedCellStyle = lipgloss.NewStyle().Background(lipgloss.Color("#558"))
+
+	defaultFilterMatchStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+	defaultGroupHeaderStyle = lipgloss.NewStyle().Bold(true)
+	defaultSummaryStyle     = lipgloss.NewStyle().Bold(true)
+	defaultPinnedRowStyle   = lipgloss.NewStyle().Italic(true)
 )
 
 // Model is the main table model.  Create using New().
@@ -22,10 +32,52 @@ type Model struct {
 	rows     []Row
 	metadata map[string]any
 
+	// The index in rows of each top level row with a user-defined ID, built
+	// when first needed and kept up to date when rows are mutated
+	rowsIndexByID map[string]int
+
+	// Whether any rows have child rows, which means the first column shows
+	// the tree structure
+	treeRows bool
+
+	// Rows collapsed by the user while filtering, which are otherwise shown
+	// expanded when any of their descendants match, along with the filter
+	// they were collapsed for.  Replaced rather than modified since copies of
+	// the model share them.
+	filterCollapsedRows   map[uint32]bool
+	filterCollapsedFilter string
+
 	// Caches for optimizations
 	visibleRowCacheUpdated bool
 	visibleRowCache        []Row
 
+	// The visible rows with group headers, if grouping, along with the index
+	// in the visible rows of each grouped row and the reverse
+	groupedRowCache       []Row
+	groupedRowIndexes     []int
+	groupedDisplayIndexes []int
+
+	// Column aggregates of the visible rows, if the summary row is shown
+	summaryValueCache map[string]any
+
+	// The index of the first displayed row on each page if detail panels are
+	// shown, or nil if every page has pageSize rows
+	detailPageStartsCache []int
+
+	// Rows loaded on demand, where rows holds only the rows that have been
+	// loaded starting at rowSourceOffset, and rowSourceSavedRows holds the
+	// selection and expansion of rows by ID so that they're kept across pages
+	rowSource               RowSource
+	rowSourceID             uint32
+	rowSourceCount          int
+	rowSourceOffset         int
+	rowSourceLoadedQueryKey string
+	rowSourceRequest        rowSourceRequest
+	rowSourceInitRequest    *rowSourceRequest
+	rowSourceSavedRows      map[string]Row
+	rowSourceError          error
+	loadingRow              Row
+
 	// Shown when data is missing from a row
 	missingDataIndicator any
 
@@ -48,17 +100,52 @@ type Model struct {
 	selectableRows bool
 	rowCursorIndex int
 
+	// The currently chosen column, used for interactive sorting, resizing,
+	// arranging, and the cell cursor
+	columnCursorIndex int
+
+	// If true, the column chosen by the column cursor can be resized
+	columnResizing bool
+
+	// Moving, hiding, and showing columns, where the order includes hidden
+	// columns and is nil until columns are arranged
+	columnArranging    bool
+	columnOrder        []string
+	hiddenColumns      map[string]Column
+	columnChooserOpen  bool
+	columnChooserIndex int
+
+	// How many rows are measured for auto columns, where 0 is all rows, and
+	// the measured widths, which are nil until measured and are replaced
+	// rather than modified since copies of the model share them
+	autoColumnSampleSize int
+	autoColumnWidths     map[autoColumnWidthKey]int
+
+	// If true, a single cell is highlighted and can be moved left and right
+	cellCursor bool
+
+	// Inline cell editing, where the editor is nil when not editing
+	cellEditor    CellEditor
+	editRowID     uint32
+	editColumnKey string
+	editError     error
+
+	// If true, mouse clicks and the scroll wheel interact with the table
+	mouseSupport bool
+
 	// Events
 	lastUpdateUserEvents []UserEvent
 
 	// Styles
-	baseStyle      lipgloss.Style
-	highlightStyle lipgloss.Style
-	headerStyle    lipgloss.Style
-	rowStyleFunc   func(RowStyleFuncInput) lipgloss.Style
-	border         Border
-	selectedText   string
-	unselectedText string
+	baseStyle            lipgloss.Style
+	highlightStyle       lipgloss.Style
+	highlightedCellStyle lipgloss.Style
+	headerStyle          lipgloss.Style
+	headerHighlightStyle lipgloss.Style
+	rowStyleFunc         func(RowStyleFuncInput) lipgloss.Style
+	border               Border
+	selectedText         string
+	unselectedText       string
 
 	// Header
 	headerVisible bool
@@ -76,11 +163,67 @@ type Model struct {
 	// that elements are grouped by the later elements.
 	sortOrder []SortColumn
 
+	// Interactive sorting from the keyboard, with indicators in the header
+	interactiveSorting bool
+	sortIndicatorAsc   string
+	sortIndicatorDesc  string
+
 	// Filter
 	filtered        bool
 	filterTextInput textinput.Model
 	filterFunc      FilterFunc
 
+	// Which built-in filter function is used, if any, so that parse errors
+	// and matches can be shown
+	filterKind filterKind
+
+	// Only set on copies of the model that are filtering or rendering rows
+	parsedFilterQuery parsedFilterQuery
+
+	// Summary row of column aggregates beneath the rows
+	summaryRow   bool
+	summaryStyle lipgloss.Style
+
+	// Rows that are always shown above or beneath the other rows
+	pinnedRowsTop    []Row
+	pinnedRowsBottom []Row
+	pinnedRowStyle   lipgloss.Style
+
+	// Where rows are written when the user presses the export key
+	exportOptions ExportOptions
+	exportOpen    func() (io.WriteCloser, error)
+
+	// Copying to the clipboard with the copy keys
+	clipboardCopy        bool
+	clipboardOutput      io.Writer
+	clipboardManualWrite bool
+	copySerializer       CopySerializer
+
+	// Detail panels shown beneath rows that have their details expanded
+	rowDetailFunc  RowDetailFunc
+	rowDetailStyle lipgloss.Style
+
+	// Grouping rows by the value of a column, where collapsed groups are keyed
+	// by the group's value as a string
+	groupByColumnKey string
+	groupAggregates  []GroupAggregate
+	groupHeaderStyle lipgloss.Style
+	collapsedGroups  map[string]bool
+
+	// Highlights the parts of cells that match the filter
+	filterMatchHighlight bool
+	filterMatchStyle     lipgloss.Style
+
+	// Filtering and sorting in the background, where the applied values are
+	// what the visible rows currently reflect
+	asyncFiltering        bool
+	asyncFilterDebounce   time.Duration
+	asyncFilterTableID    uint32
+	asyncFilterPendingKey string
+	asyncAppliedKey       string
+	asyncAppliedFilter    string
+	asyncAppliedSortOrder []SortColumn
+
 	// For flex columns
 	targetTotalWidth int
 
@@ -116,13 +259,19 @@ func New(columns []Column) Model {
 	filterInput := textinput.New()
 	filterInput.Prompt = "/"
 	model := Model{
-		columns:        make([]Column, len(columns)),
-		metadata:       make(map[string]any),
-		highlightStyle: defaultHighlightStyle.Copy(),
-		border:         borderDefault,
-		headerVisible:  true,
-		footerVisible:  true,
-		keyMap:         DefaultKeyMap(),
+		columns:              make([]Column, len(columns)),
+		metadata:             make(map[string]any),
+		highlightStyle:       defaultHighlightStyle.Copy(),
+		highlightedCellStyle: defaultHighlightedCellStyle.Copy(),
+		filterMatchStyle:     defaultFilterMatchStyle.Copy(),
+		groupHeaderStyle:     defaultGroupHeaderStyle.Copy(),
+		summaryStyle:         defaultSummaryStyle.Copy(),
+		pinnedRowStyle:       defaultPinnedRowStyle.Copy(),
+		headerHighlightStyle: defaultHighlightStyle.Copy(),
+		border:               borderDefault,
+		headerVisible:        true,
+		footerVisible:        true,
+		keyMap:               DefaultKeyMap(),
 
 		selectedText:   "[x]",
 		unselectedText: "[ ]",
@@ -132,6 +281,8 @@ func New(columns []Column) Model {
 		baseStyle:       lipgloss.NewStyle().Align(lipgloss.Right),
 
 		paginationWrapping: true,
+
+		autoColumnSampleSize: defaultAutoColumnSampleSize,
 	}
 
 	// Do a full deep copy to avoid unexpected edits
@@ -142,7 +293,17 @@ func New(columns []Column) Model {
 	return model
 }
 
-// Init initializes the table per the Bubble Tea architecture.
+// Init initializes the table per the Bubble Tea architecture.  If a row source
+// is set, this returns a command to load the first rows.  If async filtering is
+// enabled, this returns a command to apply any pending filtering or sorting.
 func (m Model) Init() tea.Cmd {
-	return nil
+	loadCmd := m.loadRowSourceCmd()
+
+	// Init can't return the model, so share the request with the model's
+	// copies to avoid fetching the same rows again on the next update
+	if m.rowSourceInitRequest != nil {
+		*m.rowSourceInitRequest = m.rowSourceRequest
+	}
+
+	return batchCmds(loadCmd, m.asyncFilterCmd())
 }
@@ -0,0 +1,361 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+// columnSpan describes where a rendered column sits horizontally.  The span
+// includes the column's right border, and the left border of the table if the
+// column is the first one rendered.
+type columnSpan struct {
+	// columnIndex is the index into the model's columns, or -1 for overflow
+	// columns that aren't real columns
+	columnIndex int
+	key         string
+
+	// start is inclusive, end is exclusive
+	start int
+	end   int
+}
+
+// columnLayout calculates the horizontal position of each rendered column,
+// mirroring the rules used when rendering headers and rows.
+func (m Model) columnLayout() []columnSpan {
+	spans := []columnSpan{}
+	x := 0
+
+	add := func(columnIndex int, key string, renderedWidth int) {
+		spans = append(spans, columnSpan{
+			columnIndex: columnIndex,
+			key:         key,
+			start:       x,
+			end:         x + renderedWidth,
+		})
+
+		x += renderedWidth
+	}
+
+	renderedWidthOf := func(width int) int {
+		const borderAdjustment = 1
+
+		if len(spans) == 0 {
+			// The first column also has the left border of the table
+			return width + borderAdjustment*2
+		}
+
+		return width + borderAdjustment
+	}
+
+	for columnIndex, column := range m.columns {
+		if m.horizontalScrollOffsetCol > 0 && columnIndex == m.horizontalScrollFreezeColumnsCount {
+			add(-1, columnKeyOverflowLeft, renderedWidthOf(1))
+		}
+
+		if columnIndex >= m.horizontalScrollFreezeColumnsCount &&
+			columnIndex < m.horizontalScrollOffsetCol+m.horizontalScrollFreezeColumnsCount {
+			continue
+		}
+
+		renderedWidth := renderedWidthOf(column.width)
+
+		if m.maxTotalWidth != 0 {
+			const (
+				borderAdjustment = 1
+				overflowColWidth = 2
+			)
+
+			targetWidth := m.maxTotalWidth - overflowColWidth
+
+			if columnIndex == len(m.columns)-1 {
+				targetWidth = m.maxTotalWidth
+			}
+
+			if x+renderedWidth > targetWidth {
+				overflowWidth := m.maxTotalWidth - x - borderAdjustment
+
+				add(-1, columnKeyOverflowRight, overflowWidth+borderAdjustment)
+
+				break
+			}
+		}
+
+		add(columnIndex, column.key, renderedWidth)
+	}
+
+	return spans
+}
+
+// columnSpanAt returns the rendered column at the given horizontal position,
+// if any.
+func (m Model) columnSpanAt(x int) (columnSpan, bool) {
+	for _, span := range m.columnLayout() {
+		if x >= span.start && x < span.end {
+			return span, true
+		}
+	}
+
+	return columnSpan{}, false
+}
+
+// viewSectionBounds is where a section of the table sits vertically.
+type viewSectionBounds struct {
+	kind     viewSectionKind
+	rowIndex int
+	top      int
+	height   int
+}
+
+// sectionLayout calculates the vertical position of each section of the table,
+// mirroring renderSections.  Rows and padding are one line plus any bottom
+// border, so only multiline rows are rendered to measure them.
+//
+//nolint:cyclop
+func (m Model) sectionLayout() []viewSectionBounds {
+	if len(m.columns) == 0 {
+		return nil
+	}
+
+	plan := m.planViewRows()
+	headers := m.renderHeaders()
+	layout := []viewSectionBounds{}
+	top := 0
+
+	add := func(kind viewSectionKind, rowIndex int, height int) {
+		layout = append(layout, viewSectionBounds{
+			kind:     kind,
+			rowIndex: rowIndex,
+			top:      top,
+			height:   height,
+		})
+
+		top += height
+	}
+
+	// A single line, with the table's bottom border beneath it if it's last
+	lineHeight := func(last bool) int {
+		if last {
+			return 2
+		}
+
+		return 1
+	}
+
+	if m.headerVisible {
+		add(viewSectionHeader, 0, lipgloss.Height(headers))
+	} else if m.hasHeaderSection(plan) {
+		add(viewSectionHeader, 0, 1)
+	}
+
+	for i, row := range m.pinnedRowsTop {
+		last := i == len(m.pinnedRowsTop)-1 && plan.numRows == 0 && plan.padding == 0 && !plan.hasRowsBeneath
+
+		add(viewSectionPinned, 0, lipgloss.Height(m.renderPinnedRow(row, last)))
+	}
+
+	for i := plan.startRowIndex; i <= plan.endRowIndex; i++ {
+		last := plan.padding == 0 && i == plan.endRowIndex && !plan.hasRowsBeneath
+		detail, hasDetail := plan.details[i]
+
+		if m.multiline {
+			add(viewSectionRow, i, lipgloss.Height(m.renderRow(i, last && !hasDetail)))
+		} else {
+			add(viewSectionRow, i, lineHeight(last && !hasDetail))
+		}
+
+		if hasDetail {
+			add(viewSectionDetail, i, lipgloss.Height(detail)+lineHeight(last)-1)
+		}
+	}
+
+	for i := 1; i <= plan.padding; i++ {
+		add(viewSectionPadding, 0, lineHeight(i == plan.padding && !plan.hasRowsBeneath))
+	}
+
+	for i, row := range m.pinnedRowsBottom {
+		last := i == len(m.pinnedRowsBottom)-1 && !m.summaryRow
+
+		add(viewSectionPinned, 0, lipgloss.Height(m.renderPinnedRow(row, last)))
+	}
+
+	if m.summaryRow {
+		add(viewSectionSummary, 0, lipgloss.Height(m.renderSummaryRow(plan.summaryDivider || plan.padding > 0)))
+	}
+
+	if footer := m.renderFooter(lipgloss.Width(headers), len(layout) == 0); footer != "" {
+		add(viewSectionFooter, 0, lipgloss.Height(footer))
+	}
+
+	return layout
+}
+
+// sectionAt returns the section at the given vertical position, along with
+// the line offset within that section.
+func (m Model) sectionAt(y int) (viewSectionBounds, int, bool) {
+	for _, section := range m.sectionLayout() {
+		if y >= section.top && y < section.top+section.height {
+			return section, y - section.top, true
+		}
+	}
+
+	return viewSectionBounds{}, 0, false
+}
+
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	if !m.mouseSupport {
+		return
+	}
+
+	previousRowIndex := m.rowCursorIndex
+
+	switch msg.Type {
+	case tea.MouseWheelUp:
+		if m.rowCursorIndex > 0 {
+			m.moveHighlightUp()
+		}
+
+	case tea.MouseWheelDown:
+		if m.rowCursorIndex < m.visibleRowCount()-1 {
+			m.moveHighlightDown()
+		}
+
+	case tea.MouseLeft, tea.MouseRight:
+		m.handleMouseClick(msg)
+	}
+
+	if m.rowCursorIndex != previousRowIndex {
+		m.appendUserEvent(UserEventHighlightedIndexChanged{
+			PreviousRowIndex: m.rowIndex(previousRowIndex),
+			SelectedRowIndex: m.rowIndex(m.rowCursorIndex),
+		})
+	}
+}
+
+func (m *Model) handleMouseClick(msg tea.MouseMsg) {
+	section, lineOffset, found := m.sectionAt(msg.Y)
+
+	if !found {
+		return
+	}
+
+	if section.kind == viewSectionFooter {
+		m.handleMouseClickFooter(msg, section, lineOffset)
+
+		return
+	}
+
+	// Only footer page indicators react to anything other than a left click
+	if msg.Type != tea.MouseLeft {
+		return
+	}
+
+	span, found := m.columnSpanAt(msg.X)
+
+	if !found {
+		return
+	}
+
+	switch span.key {
+	case columnKeyOverflowLeft:
+		m.scrollLeft()
+
+		return
+
+	case columnKeyOverflowRight:
+		m.scrollRight()
+
+		return
+	}
+
+	switch section.kind {
+	case viewSectionHeader:
+		if !m.headerVisible {
+			return
+		}
+
+		m.appendUserEvent(UserEventHeaderClicked{
+			ColumnIndex: span.columnIndex,
+			ColumnKey:   span.key,
+		})
+
+		// Tables sorted by the app shouldn't lose their sorting to a click
+		if m.interactiveSorting && span.key != columnKeySelect {
+			m.columnCursorIndex = span.columnIndex
+			m.cycleSort(span.key, false)
+		}
+
+	case viewSectionRow:
+		m.rowCursorIndex = section.rowIndex
+
+		if m.cellCursor && span.key != columnKeySelect {
+			m.columnCursorIndex = span.columnIndex
+		}
+
+		m.appendUserEvent(UserEventRowClicked{
+			RowIndex:  m.rowIndex(section.rowIndex),
+			ColumnKey: span.key,
+		})
+
+		if span.key == columnKeySelect {
+			m.toggleSelect()
+		}
+	}
+}
+
+// handleMouseClickFooter pages forward on a left click of the page indicator,
+// and backwards on a right click.
+func (m *Model) handleMouseClickFooter(msg tea.MouseMsg, section viewSectionBounds, lineOffset int) {
+	if m.pageSize == 0 || m.staticFooter != "" {
+		return
+	}
+
+	// The footer only has its own top border if it's all that's shown
+	footer := m.renderFooter(lipgloss.Width(m.renderHeaders()), section.top == 0)
+	lines := strings.Split(footer, "\n")
+	line := stripANSI(lines[lineOffset])
+	indicator := fmt.Sprintf("%d/%d", m.CurrentPage(), m.MaxPages())
+	indicatorIndex := strings.LastIndex(line, indicator)
+
+	if indicatorIndex == -1 {
+		return
+	}
+
+	start := ansi.PrintableRuneWidth(line[:indicatorIndex])
+	end := start + ansi.PrintableRuneWidth(indicator)
+
+	if msg.X < start || msg.X >= end {
+		return
+	}
+
+	if msg.Type == tea.MouseLeft {
+		m.pageDown()
+	} else {
+		m.pageUp()
+	}
+}
+
+func stripANSI(str string) string {
+	builder := strings.Builder{}
+	inSequence := false
+
+	for _, r := range str {
+		switch {
+		case r == ansi.Marker:
+			inSequence = true
+
+		case inSequence:
+			if ansi.IsTerminator(r) {
+				inSequence = false
+			}
+
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
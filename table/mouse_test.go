@@ -0,0 +1,285 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genMouseTestModel(numRows int) Model {
+	rows := []Row{}
+
+	for i := 1; i <= numRows; i++ {
+		rows = append(rows, NewRow(RowData{
+			"id":    fmt.Sprintf("%d", i),
+			"name":  fmt.Sprintf("name%d", numRows-i),
+			"score": i * 10,
+		}))
+	}
+
+	return New([]Column{
+		NewColumn("id", "ID", 4),
+		NewColumn("name", "Name", 6),
+		NewColumn("score", "Score", 5),
+	}).WithRows(rows).Focused(true).WithMouseSupport(true)
+}
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Type: tea.MouseLeft}
+}
+
+func TestMouseIgnoredWhenNotEnabled(t *testing.T) {
+	model := genMouseTestModel(3).WithMouseSupport(false)
+
+	model, _ = model.Update(click(2, 4))
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+}
+
+func TestMouseClickRowHighlightsRow(t *testing.T) {
+	model := genMouseTestModel(3)
+
+	// Header takes 3 lines, so the second row is on line 4
+	model, _ = model.Update(click(8, 4))
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+
+	events := model.GetLastUpdateUserEvents()
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, UserEventRowClicked{RowIndex: 1, ColumnKey: "name"}, events[0])
+	assert.Equal(t, UserEventHighlightedIndexChanged{PreviousRowIndex: 0, SelectedRowIndex: 1}, events[1])
+
+	// Clicking the last row's bottom border still counts as the last row
+	model, _ = model.Update(click(1, 6))
+
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+}
+
+func TestMouseClickOutsideTableDoesNothing(t *testing.T) {
+	model := genMouseTestModel(3)
+
+	model, _ = model.Update(click(100, 4))
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+
+	model, _ = model.Update(click(2, 100))
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+}
+
+func TestMouseRightClickOnRowDoesNothing(t *testing.T) {
+	model := genMouseTestModel(3)
+
+	model, _ = model.Update(tea.MouseMsg{X: 2, Y: 4, Type: tea.MouseRight})
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+}
+
+func TestMouseClickHeaderSorts(t *testing.T) {
+	model := genMouseTestModel(3).WithInteractiveSorting(true)
+
+	// Name column starts at x=6
+	model, _ = model.Update(click(7, 1))
+
+	assert.Equal(t, []UserEvent{
+		UserEventHeaderClicked{ColumnIndex: 1, ColumnKey: "name"},
+		UserEventSortChanged{SortColumns: []SortColumn{{ColumnKey: "name", Direction: SortDirectionAsc}}},
+	}, model.GetLastUpdateUserEvents())
+	assert.Equal(t, []SortColumn{{ColumnKey: "name", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+	assert.Equal(t, "name0", model.GetVisibleRows()[0].Data["name"])
+
+	model, _ = model.Update(click(7, 1))
+
+	assert.Equal(t, []SortColumn{{ColumnKey: "name", Direction: SortDirectionDesc}}, model.GetColumnSorting())
+	assert.Equal(t, "name2", model.GetVisibleRows()[0].Data["name"])
+
+	model, _ = model.Update(click(7, 1))
+
+	assert.Len(t, model.GetColumnSorting(), 0)
+	assert.Equal(t, "name2", model.GetVisibleRows()[0].Data["name"])
+
+	// Clicking a different column switches to it
+	model, _ = model.Update(click(14, 1))
+
+	assert.Equal(t, []SortColumn{{ColumnKey: "score", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+}
+
+func TestMouseClickHeaderKeepsFixedSort(t *testing.T) {
+	model := genMouseTestModel(3).SortByDesc("score")
+
+	model, _ = model.Update(click(7, 1))
+
+	assert.Equal(t, []UserEvent{
+		UserEventHeaderClicked{ColumnIndex: 1, ColumnKey: "name"},
+	}, model.GetLastUpdateUserEvents())
+	assert.Equal(t, []SortColumn{{ColumnKey: "score", Direction: SortDirectionDesc}}, model.GetColumnSorting())
+}
+
+func TestMouseClickHiddenHeaderDoesNothing(t *testing.T) {
+	model := genMouseTestModel(3).WithHeaderVisibility(false)
+
+	// Only the top border remains, so the first row is on line 1
+	model, _ = model.Update(click(7, 0))
+
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+	assert.Len(t, model.GetColumnSorting(), 0)
+
+	model, _ = model.Update(click(7, 2))
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+}
+
+func TestMouseClickSelectColumnTogglesSelection(t *testing.T) {
+	model := genMouseTestModel(3).SelectableRows(true).WithInteractiveSorting(true)
+
+	model, _ = model.Update(click(1, 5))
+
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+	assert.Contains(t, model.GetLastUpdateUserEvents(), UserEventRowSelectToggled{RowIndex: 2, IsSelected: true})
+	assert.Len(t, model.SelectedRows(), 1)
+
+	// Clicking the select header doesn't sort
+	model, _ = model.Update(click(1, 1))
+
+	assert.Len(t, model.GetColumnSorting(), 0)
+}
+
+func TestMouseWheelMovesHighlightWithoutWrapping(t *testing.T) {
+	model := genMouseTestModel(3)
+
+	wheelUp := tea.MouseMsg{Type: tea.MouseWheelUp}
+	wheelDown := tea.MouseMsg{Type: tea.MouseWheelDown}
+
+	model, _ = model.Update(wheelUp)
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+
+	model, _ = model.Update(wheelDown)
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetLastUpdateUserEvents(), 1)
+
+	model, _ = model.Update(wheelDown)
+	model, _ = model.Update(wheelDown)
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(wheelUp)
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+}
+
+func TestMouseClickOverflowColumnsScroll(t *testing.T) {
+	model := genMouseTestModel(3).WithMaxTotalWidth(16)
+
+	const expectedStart = `┏━━━━┳━━━━━━┳━━┓
+┃  ID┃  Name┃ >┃`
+
+	assert.Equal(t, expectedStart, model.View()[:len(expectedStart)])
+
+	model, _ = model.Update(click(14, 1))
+	assert.Equal(t, 1, model.GetHorizontalScrollColumnOffset())
+
+	// Once scrolled, the left overflow column is at the start
+	model, _ = model.Update(click(1, 4))
+	assert.Equal(t, 0, model.GetHorizontalScrollColumnOffset())
+	assert.Equal(t, 0, model.GetHighlightedRowIndex(), "Should not have highlighted a row")
+}
+
+func TestMouseClickFooterPageIndicator(t *testing.T) {
+	model := genMouseTestModel(5).WithPageSize(2)
+
+	const expectedTable = `┏━━━━┳━━━━━━┳━━━━━┓
+┃  ID┃  Name┃Score┃
+┣━━━━╋━━━━━━╋━━━━━┫
+┃   1┃ name4┃   10┃
+┃   2┃ name3┃   20┃
+┣━━━━┻━━━━━━┻━━━━━┫
+┃              1/3┃
+┗━━━━━━━━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	// Clicking the blank part of the footer does nothing
+	model, _ = model.Update(click(3, 6))
+	assert.Equal(t, 1, model.CurrentPage())
+
+	model, _ = model.Update(click(15, 6))
+	assert.Equal(t, 2, model.CurrentPage())
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	model, _ = model.Update(tea.MouseMsg{X: 15, Y: 6, Type: tea.MouseRight})
+	assert.Equal(t, 1, model.CurrentPage())
+
+	model, _ = model.Update(tea.MouseMsg{X: 15, Y: 6, Type: tea.MouseRight})
+	assert.Equal(t, 3, model.CurrentPage())
+}
+
+func TestMouseColumnLayoutMatchesRenderedHeader(t *testing.T) {
+	model := genMouseTestModel(3).
+		WithMaxTotalWidth(16).
+		WithHorizontalFreezeColumnCount(1).
+		ScrollRight()
+
+	header := stripANSI(model.renderHeaders())
+	titleLine := []rune(strings.Split(header, "\n")[1])
+
+	for _, span := range model.columnLayout() {
+		assert.Equal(t, '┃', titleLine[span.end-1], "Span %q should end on a border", span.key)
+	}
+}
+
+func TestMouseSectionLayoutMatchesRenderedSections(t *testing.T) {
+	detail := func(input RowDetailFuncInput) string {
+		return "detail\nof " + input.Row.Data["id"].(string)
+	}
+
+	models := map[string]Model{
+		"plain":  genMouseTestModel(3),
+		"paged":  genMouseTestModel(5).WithPageSize(2),
+		"hidden": genMouseTestModel(3).WithHeaderVisibility(false),
+		"padded": genMouseTestModel(2).WithMinimumHeight(12).WithSummaryRow(true),
+		"pinned": genMouseTestModel(2).
+			WithPinnedRowsTop(NewRow(RowData{"id": "top"})).
+			WithPinnedRowsBottom(NewRow(RowData{"id": "bot"})),
+		"details": genMouseTestModel(0).WithRowDetail(detail).WithRows([]Row{
+			NewRow(RowData{"id": "1"}).WithDetailExpanded(true),
+			NewRow(RowData{"id": "2"}),
+			NewRow(RowData{"id": "3"}).WithDetailExpanded(true),
+		}),
+		"multiline": genMouseTestModel(2).WithMultiline(true).WithRows([]Row{
+			NewRow(RowData{"id": "1", "name": "a very long name"}),
+			NewRow(RowData{"id": "2", "name": "b"}),
+		}),
+		"empty": genMouseTestModel(0).WithStaticFooter("nothing"),
+	}
+
+	for name, model := range models {
+		t.Run(name, func(t *testing.T) {
+			sections := model.renderSections()
+			layout := model.sectionLayout()
+
+			if !assert.Len(t, layout, len(sections)) {
+				return
+			}
+
+			top := 0
+
+			for i, section := range sections {
+				height := strings.Count(section.rendered, "\n") + 1
+
+				assert.Equal(t, viewSectionBounds{
+					kind:     section.kind,
+					rowIndex: section.rowIndex,
+					top:      top,
+					height:   height,
+				}, layout[i])
+
+				top += height
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package table
+
+import "sort"
+
+// InsertRows adds the given rows to the end of the table's data.  Unlike
+// WithRows, the existing sorting and filtering results are updated in place
+// rather than recalculated for every row, which makes this suitable for
+// frequent updates to large tables.  The highlight stays on the same row.
+//
+// Earlier copies of the model and rows previously returned by GetVisibleRows
+// aren't changed, since the rows are copied before being changed.  This is
+// still much cheaper than sorting and filtering every row again.
+func (m Model) InsertRows(rows ...Row) Model {
+	if len(rows) == 0 || m.rowSource != nil {
+		return m
+	}
+
+	m.GetVisibleRows()
+	m.copyRowsForMutation()
+
+	highlightedInternalID := m.highlightedInternalID()
+	inPlace := m.canMutateVisibleRowCache()
+
+	for _, row := range rows {
+		rowsIndex := len(m.rows)
+
+		m.rows = append(m.rows, row)
+		m.indexRowID(row.userID, rowsIndex)
+
+		inPlace = inPlace && len(row.children) == 0
+
+		if inPlace && m.isRowVisibleWithFilter(row) {
+			m.insertIntoVisibleRowCache(row, rowsIndex)
+		}
+	}
+
+	m.finishMutatingRows(inPlace, highlightedInternalID)
+
+	return m
+}
+
+// UpdateRow replaces the row with the same ID set by Row.WithID.  The row keeps
+// its selection, and the existing sorting and filtering results are updated
+// in place.  If no row with the same ID exists, nothing happens.  See
+// InsertRows for how the table's rows are updated.
+func (m Model) UpdateRow(row Row) Model {
+	return m.upsertRows([]Row{row}, false)
+}
+
+// UpsertRows replaces any rows with the same ID set by Row.WithID, and adds any
+// rows that don't exist yet to the end of the table's data.  Existing rows keep
+// their selection, and the existing sorting and filtering results are updated
+// in place.  See InsertRows for how the table's rows are updated.
+func (m Model) UpsertRows(rows ...Row) Model {
+	return m.upsertRows(rows, true)
+}
+
+// DeleteRows removes the rows with any of the given IDs set by Row.WithID.  If
+// the highlighted row is removed, the highlight moves to the next row.  See
+// InsertRows for how the table's rows are updated.
+func (m Model) DeleteRows(ids ...string) Model {
+	if len(ids) == 0 || m.rowSource != nil {
+		return m
+	}
+
+	m.GetVisibleRows()
+
+	toDelete := make(map[int]bool, len(ids))
+	firstDeleted := len(m.rows)
+
+	for _, id := range ids {
+		if rowsIndex, exists := m.rowsIndexOfID(id); exists {
+			toDelete[rowsIndex] = true
+			firstDeleted = min(firstDeleted, rowsIndex)
+		}
+	}
+
+	if len(toDelete) == 0 {
+		return m
+	}
+
+	m.copyRowsForMutation()
+
+	highlightedInternalID := m.highlightedInternalID()
+	inPlace := m.canMutateVisibleRowCache()
+
+	if inPlace {
+		// Remove from the visible rows first, while the indexes still match
+		for rowsIndex := range toDelete {
+			m.removeFromVisibleRowCache(m.rows[rowsIndex], rowsIndex)
+		}
+	}
+
+	kept := m.rows[:firstDeleted]
+
+	for rowsIndex := firstDeleted; rowsIndex < len(m.rows); rowsIndex++ {
+		row := m.rows[rowsIndex]
+
+		if toDelete[rowsIndex] {
+			delete(m.rowsIndexByID, row.userID)
+
+			continue
+		}
+
+		m.indexRowID(row.userID, len(kept))
+		kept = append(kept, row)
+	}
+
+	m.rows = kept
+
+	m.finishMutatingRows(inPlace, highlightedInternalID)
+
+	return m
+}
+
+func (m Model) upsertRows(rows []Row, insertMissing bool) Model {
+	if len(rows) == 0 || m.rowSource != nil {
+		return m
+	}
+
+	m.GetVisibleRows()
+
+	highlightedInternalID := m.highlightedInternalID()
+	inPlace := m.canMutateVisibleRowCache()
+	changed := false
+
+	for _, row := range rows {
+		rowsIndex, exists := m.rowsIndexOfID(row.userID)
+
+		if !changed && (exists || insertMissing) {
+			m.copyRowsForMutation()
+		}
+
+		if exists {
+			previous := m.rows[rowsIndex]
+			row = row.withStateFrom(previous)
+
+			if inPlace {
+				m.removeFromVisibleRowCache(previous, rowsIndex)
+			}
+
+			m.rows[rowsIndex] = row
+		} else {
+			if !insertMissing {
+				continue
+			}
+
+			rowsIndex = len(m.rows)
+
+			m.rows = append(m.rows, row)
+			m.indexRowID(row.userID, rowsIndex)
+		}
+
+		changed = true
+		inPlace = inPlace && len(row.children) == 0
+
+		if inPlace && m.isRowVisibleWithFilter(row) {
+			position := m.insertIntoVisibleRowCache(row, rowsIndex)
+
+			// The highlighted row may have moved, so follow it
+			if row.id == highlightedInternalID {
+				m.rowCursorIndex = position
+			}
+		}
+	}
+
+	if !changed {
+		return m
+	}
+
+	m.finishMutatingRows(inPlace, highlightedInternalID)
+
+	return m
+}
+
+// copyRowsForMutation copies the rows, the index of rows by ID, and the visible
+// row cache before they're changed in place, since earlier copies of the model
+// and rows returned by GetVisibleRows share them.
+func (m *Model) copyRowsForMutation() {
+	rows := make([]Row, len(m.rows))
+	copy(rows, m.rows)
+	m.rows = rows
+
+	if m.rowsIndexByID != nil {
+		rowsIndexByID := make(map[string]int, len(m.rowsIndexByID))
+
+		for id, rowsIndex := range m.rowsIndexByID {
+			rowsIndexByID[id] = rowsIndex
+		}
+
+		m.rowsIndexByID = rowsIndexByID
+	}
+
+	if m.visibleRowCacheUpdated {
+		visibleRowCache := make([]Row, len(m.visibleRowCache))
+		copy(visibleRowCache, m.visibleRowCache)
+		m.visibleRowCache = visibleRowCache
+	}
+}
+
+// rowsIndexOfID returns the index in the model's rows of the top level row with
+// the given user-defined ID.
+func (m *Model) rowsIndexOfID(id string) (int, bool) {
+	if id == "" {
+		return 0, false
+	}
+
+	rowsIndex, exists := m.rowsIndexByID[id]
+
+	// The index is shared with earlier copies of the model, so rebuild it if
+	// it doesn't match these rows
+	if m.rowsIndexByID == nil || (exists && (rowsIndex >= len(m.rows) || m.rows[rowsIndex].userID != id)) {
+		m.rowsIndexByID = make(map[string]int, len(m.rows))
+
+		for i, row := range m.rows {
+			m.indexRowID(row.userID, i)
+		}
+
+		rowsIndex, exists = m.rowsIndexByID[id]
+	}
+
+	return rowsIndex, exists
+}
+
+// indexRowID adds the row with the given user-defined ID to the index of rows
+// by ID, unless the index hasn't been built yet.  If IDs are repeated, the
+// first row with the ID is kept.
+func (m *Model) indexRowID(id string, rowsIndex int) {
+	if id == "" || m.rowsIndexByID == nil {
+		return
+	}
+
+	if existing, exists := m.rowsIndexByID[id]; !exists || existing >= rowsIndex {
+		m.rowsIndexByID[id] = rowsIndex
+	}
+}
+
+// rowsIndexOf returns the index of the given top level row in the model's
+// rows, or -1 if it's not found.
+func (m *Model) rowsIndexOf(row Row) int {
+	if rowsIndex, exists := m.rowsIndexOfID(row.userID); exists && m.rows[rowsIndex].id == row.id {
+		return rowsIndex
+	}
+
+	for i := range m.rows {
+		if m.rows[i].id == row.id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// canMutateVisibleRowCache returns true if the visible row cache can be
+// updated in place as rows change, which isn't possible for trees or groups.
+func (m *Model) canMutateVisibleRowCache() bool {
+	return !m.treeRows && !m.isGrouped()
+}
+
+// visibleRowCachePosition returns the position of the given row in the sorted
+// visible row cache, where rowsIndex is the index of the row in the model's
+// rows, which keeps equal rows in the same order as a stable sort.
+func (m *Model) visibleRowCachePosition(row Row, rowsIndex int) int {
+	cache := m.visibleRowCache
+	sortOrder := m.appliedSortOrder()
+	sorts := m.columnSorts()
+	isLast := rowsIndex == len(m.rows)-1
+
+	return sort.Search(len(cache), func(cacheIndex int) bool {
+		cmp := compareRows(sortOrder, sorts, row, cache[cacheIndex])
+
+		if cmp != 0 {
+			return cmp < 0
+		}
+
+		// Rows added to the end come after all equal rows
+		if isLast {
+			return cache[cacheIndex].id == row.id
+		}
+
+		return rowsIndex <= m.rowsIndexOf(cache[cacheIndex])
+	})
+}
+
+// insertIntoVisibleRowCache inserts a row into the sorted and filtered visible
+// row cache in the same position that a full recalculation would put it,
+// keeping the cursor on the same row.  The row must already be in the model's
+// rows at rowsIndex.  Returns the position the row was inserted at.
+func (m *Model) insertIntoVisibleRowCache(row Row, rowsIndex int) int {
+	position := m.visibleRowCachePosition(row, rowsIndex)
+
+	if len(m.visibleRowCache) > 0 && position <= m.rowCursorIndex {
+		m.rowCursorIndex++
+	}
+
+	m.visibleRowCache = append(m.visibleRowCache, Row{})
+	copy(m.visibleRowCache[position+1:], m.visibleRowCache[position:])
+	m.visibleRowCache[position] = row
+
+	return position
+}
+
+// removeFromVisibleRowCache removes a row from the visible row cache if it's
+// there, keeping the cursor on the same row if it wasn't removed.  The row must
+// still be in the model's rows at rowsIndex.
+func (m *Model) removeFromVisibleRowCache(row Row, rowsIndex int) {
+	if !m.isRowVisibleWithFilter(row) {
+		return
+	}
+
+	position := m.visibleRowCachePosition(row, rowsIndex)
+
+	if position >= len(m.visibleRowCache) || m.visibleRowCache[position].id != row.id {
+		// Shouldn't happen for a consistent sort, but be safe
+		position = -1
+
+		for i := range m.visibleRowCache {
+			if m.visibleRowCache[i].id == row.id {
+				position = i
+
+				break
+			}
+		}
+
+		if position == -1 {
+			return
+		}
+	}
+
+	if position < m.rowCursorIndex {
+		m.rowCursorIndex--
+	}
+
+	m.visibleRowCache = append(m.visibleRowCache[:position], m.visibleRowCache[position+1:]...)
+}
+
+// finishMutatingRows updates anything that depends on the rows after they've
+// been mutated.  If the visible row cache couldn't be updated in place because
+// the rows are or were a tree or are grouped, the visible rows are
+// recalculated.
+func (m *Model) finishMutatingRows(inPlace bool, highlightedInternalID uint32) {
+	m.remeasureAutoColumns()
+
+	if !inPlace {
+		m.treeRows = rowsHaveChildren(m.rows)
+		m.refreshRowTree(highlightedInternalID)
+
+		return
+	}
+
+	// Updates any summary values
+	m.setVisibleRowCache(m.visibleRowCache)
+
+	m.clampRowCursor()
+}
+
+// highlightedInternalID returns the internal ID of the highlighted row, or 0 if
+// there are no visible rows or a group header is highlighted.
+func (m *Model) highlightedInternalID() uint32 {
+	if m.visibleRowCount() == 0 {
+		return 0
+	}
+
+	return m.visibleRow(m.rowCursorIndex).id
+}
+
+func (m *Model) clampRowCursor() {
+	totalRows := m.visibleRowCount()
+
+	if m.rowCursorIndex >= totalRows {
+		m.rowCursorIndex = totalRows - 1
+	}
+
+	if m.rowCursorIndex < 0 {
+		m.rowCursorIndex = 0
+	}
+
+	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
+}
@@ -0,0 +1,254 @@
+package table
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genMutateRow(id string, name string, score int) Row {
+	return NewRow(RowData{"name": name, "score": score}).WithID(id)
+}
+
+func genMutateModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 8).WithFiltered(true),
+		NewColumn("score", "Score", 6),
+	}).WithRows([]Row{
+		genMutateRow("a", "alpha", 3),
+		genMutateRow("b", "bravo", 1),
+		genMutateRow("c", "charlie", 2),
+	}).SortByAsc("score").Filtered(true).SelectableRows(true).Focused(true)
+}
+
+func visibleIDs(model Model) []string {
+	ids := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		ids = append(ids, row.ID())
+	}
+
+	return ids
+}
+
+// recalculatedIDs returns the visible IDs after recalculating everything from
+// scratch, which the incremental updates should always match.
+func recalculatedIDs(model Model) []string {
+	model.visibleRowCacheUpdated = false
+
+	return visibleIDs(model)
+}
+
+func TestInsertRowsKeepsSortOrder(t *testing.T) {
+	model := genMutateModel()
+
+	assert.Equal(t, []string{"b", "c", "a"}, visibleIDs(model))
+
+	model = model.InsertRows(
+		genMutateRow("d", "delta", 0),
+		genMutateRow("e", "echo", 2),
+		genMutateRow("f", "foxtrot", 10),
+	)
+
+	assert.Equal(t, []string{"d", "b", "c", "e", "a", "f"}, visibleIDs(model))
+	assert.Equal(t, recalculatedIDs(model), visibleIDs(model))
+	assert.Equal(t, 6, model.TotalRows())
+}
+
+func TestInsertRowsRespectsFilter(t *testing.T) {
+	model := genMutateModel().WithFilterInputValue("l")
+
+	assert.Equal(t, []string{"c", "a"}, visibleIDs(model))
+
+	model = model.InsertRows(
+		genMutateRow("d", "delta", 0),
+		genMutateRow("e", "echo", 0),
+	)
+
+	assert.Equal(t, []string{"d", "c", "a"}, visibleIDs(model))
+	assert.Len(t, model.rows, 5)
+
+	// Clearing the filter should show the hidden row in its sorted place
+	model = model.WithFilterInputValue("")
+
+	assert.Equal(t, []string{"d", "e", "b", "c", "a"}, visibleIDs(model))
+}
+
+func TestInsertRowsKeepsHighlightedRow(t *testing.T) {
+	model := genMutateModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Equal(t, "c", model.HighlightedRow().ID())
+
+	model = model.InsertRows(genMutateRow("d", "delta", 0))
+
+	assert.Equal(t, "c", model.HighlightedRow().ID())
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+}
+
+func TestInsertRowsIntoEmptyTable(t *testing.T) {
+	model := New([]Column{NewColumn("name", "Name", 8)})
+
+	model = model.InsertRows(genMutateRow("a", "alpha", 0))
+
+	assert.Equal(t, []string{"a"}, visibleIDs(model))
+	assert.Equal(t, "a", model.HighlightedRow().ID())
+}
+
+func TestUpdateRowMovesToSortedPosition(t *testing.T) {
+	model := genMutateModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model = model.UpdateRow(genMutateRow("b", "bravo", 5))
+
+	assert.Equal(t, []string{"c", "a", "b"}, visibleIDs(model))
+	assert.Equal(t, recalculatedIDs(model), visibleIDs(model))
+
+	assert.Equal(t, "b", model.HighlightedRow().ID(), "Highlight should follow the updated row")
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	selected := model.SelectedRows()
+
+	assert.Len(t, selected, 1, "Should keep the selection")
+	assert.Equal(t, 5, selected[0].Data["score"])
+}
+
+func TestUpdateRowIgnoresMissingID(t *testing.T) {
+	model := genMutateModel()
+
+	model = model.UpdateRow(genMutateRow("missing", "zulu", 0))
+
+	assert.Equal(t, []string{"b", "c", "a"}, visibleIDs(model))
+	assert.Equal(t, 3, model.TotalRows())
+}
+
+func TestUpdateRowCanBeFilteredOut(t *testing.T) {
+	model := genMutateModel().WithFilterInputValue("l")
+
+	model = model.UpdateRow(genMutateRow("c", "cee", 2))
+
+	assert.Equal(t, []string{"a"}, visibleIDs(model))
+
+	model = model.UpdateRow(genMutateRow("b", "bravely", 1))
+
+	assert.Equal(t, []string{"b", "a"}, visibleIDs(model))
+}
+
+func TestDeleteRows(t *testing.T) {
+	model := genMutateModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Equal(t, "a", model.HighlightedRow().ID())
+
+	model = model.DeleteRows("b", "missing")
+
+	assert.Equal(t, []string{"c", "a"}, visibleIDs(model))
+	assert.Equal(t, "a", model.HighlightedRow().ID())
+	assert.Equal(t, 2, model.TotalRows())
+
+	model = model.DeleteRows("a")
+
+	assert.Equal(t, []string{"c"}, visibleIDs(model))
+	assert.Equal(t, "c", model.HighlightedRow().ID(), "Should move to a remaining row")
+
+	model = model.DeleteRows("c")
+
+	assert.Len(t, model.GetVisibleRows(), 0)
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+}
+
+func TestUpsertRows(t *testing.T) {
+	model := genMutateModel()
+
+	model = model.UpsertRows(
+		genMutateRow("a", "alpha", 0),
+		genMutateRow("d", "delta", 2),
+		genMutateRow("d", "delta", 4),
+	)
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, visibleIDs(model))
+	assert.Equal(t, recalculatedIDs(model), visibleIDs(model))
+	assert.Equal(t, 4, model.TotalRows())
+
+	row, found := model.RowByID("d")
+
+	assert.True(t, found)
+	assert.Equal(t, 4, row.Data["score"], "Should use the last row given for the same ID")
+}
+
+func TestMutationsDoNotModifyInputRows(t *testing.T) {
+	rows := []Row{
+		genMutateRow("a", "alpha", 3),
+		genMutateRow("b", "bravo", 1),
+	}
+
+	model := New([]Column{NewColumn("score", "Score", 6)}).WithRows(rows).SortByAsc("score")
+
+	model = model.UpdateRow(genMutateRow("a", "alpha", 0))
+	model = model.DeleteRows("b")
+	model = model.InsertRows(genMutateRow("c", "charlie", 2))
+
+	assert.Equal(t, []string{"a", "c"}, visibleIDs(model))
+	assert.Equal(t, 3, rows[0].Data["score"])
+	assert.Equal(t, "b", rows[1].ID())
+}
+
+func TestMutationsDoNotModifyEarlierCopies(t *testing.T) {
+	old := New([]Column{NewColumn("score", "Score", 6)}).WithRows([]Row{
+		genMutateRow("a", "alpha", 1),
+		genMutateRow("b", "bravo", 2),
+		genMutateRow("c", "charlie", 3),
+	}).SortByAsc("score")
+
+	// Builds the index of rows by ID so that it's shared too
+	_, found := old.RowByID("c")
+
+	assert.True(t, found)
+
+	visible := old.GetVisibleRows()
+
+	model := old.UpdateRow(genMutateRow("a", "alpha", 99)).DeleteRows("b")
+	model = model.InsertRows(genMutateRow("d", "delta", 0)).UpsertRows(genMutateRow("c", "charlie", 4))
+
+	assert.Equal(t, []string{"d", "c", "a"}, visibleIDs(model))
+	assert.Equal(t, []string{"a", "b", "c"}, visibleIDs(old))
+	assert.Equal(t, []any{1, 2, 3}, []any{old.rows[0].Data["score"], old.rows[1].Data["score"], old.rows[2].Data["score"]})
+	assert.Equal(t, "b", visible[1].ID())
+	assert.Equal(t, 3, visible[2].Data["score"])
+
+	rowsIndex, found := old.rowsIndexOfID("b")
+
+	assert.True(t, found)
+	assert.Equal(t, 1, rowsIndex)
+}
+
+func TestMutationsMatchRecalculationWithMultipleSorts(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+
+	model := New([]Column{
+		NewColumn("name", "Name", 8).WithFiltered(true),
+		NewColumn("score", "Score", 6),
+	}).SortByDesc("score").ThenSortByAsc("name").Filtered(true).WithFilterInputValue("1")
+
+	for i := 0; i < 200; i++ {
+		id := fmt.Sprintf("%d", random.Intn(50))
+		row := genMutateRow(id, fmt.Sprintf("n%d", random.Intn(20)), random.Intn(5))
+
+		switch random.Intn(3) {
+		case 0:
+			model = model.InsertRows(row)
+		case 1:
+			model = model.UpsertRows(row)
+		case 2:
+			model = model.DeleteRows(id)
+		}
+
+		assert.Equal(t, recalculatedIDs(model), visibleIDs(model), "Mismatch after step %d", i)
+	}
+}
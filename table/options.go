@@ -1,6 +1,8 @@
 package table
 
 import (
+	"sort"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
@@ -34,12 +36,13 @@ func (m Model) WithRowStyleFunc(f func(RowStyleFuncInput) lipgloss.Style) Model
 	return m
 }
 
-// WithHighlightedRow sets the highlighted row to the given index.
+// WithHighlightedRow sets the highlighted row to the given index.  If rows are
+// grouped with WithGroupBy, this is the index in GetVisibleRows.
 func (m Model) WithHighlightedRow(index int) Model {
-	m.rowCursorIndex = index
+	m.rowCursorIndex = m.displayIndex(index)
 
-	if m.rowCursorIndex >= len(m.GetVisibleRows()) {
-		m.rowCursorIndex = len(m.GetVisibleRows()) - 1
+	if m.rowCursorIndex >= m.visibleRowCount() {
+		m.rowCursorIndex = m.visibleRowCount() - 1
 	}
 
 	if m.rowCursorIndex < 0 {
@@ -58,13 +61,34 @@ func (m Model) HeaderStyle(style lipgloss.Style) Model {
 	return m
 }
 
-// WithRows sets the rows to show as data in the table.
+// WithRows sets the rows to show as data in the table.  Rows with an ID set by
+// Row.WithID keep their selection when replaced by a row with the same ID, and
+// the highlight stays on the same row if it's still visible.  Any row source set
+// by WithRowSource is removed.
 func (m Model) WithRows(rows []Row) Model {
-	m.rows = rows
+	highlightedID := m.HighlightedRow().userID
+
+	// Copy to avoid modifying the caller's rows in place
+	newRows := make([]Row, len(rows))
+	copy(newRows, rows)
+
+	m.preserveRowState(newRows, nil)
+
+	m.rowSource = nil
+	m.rows = newRows
+	m.rowsIndexByID = nil
+	m.treeRows = rowsHaveChildren(newRows)
 	m.visibleRowCacheUpdated = false
 
-	if m.rowCursorIndex >= len(m.rows) {
-		m.rowCursorIndex = len(m.rows) - 1
+	m.remeasureAutoColumns()
+
+	if index := m.displayIndexOfRowID(highlightedID); index != -1 {
+		m.rowCursorIndex = index
+		m.currentPage = m.expectedPageForRowIndex(index)
+	}
+
+	if m.rowCursorIndex >= m.visibleRowCount() {
+		m.rowCursorIndex = m.visibleRowCount() - 1
 	}
 
 	if m.rowCursorIndex < 0 {
@@ -107,8 +131,10 @@ func (m Model) SelectableRows(selectable bool) Model {
 			m.columns = append([]Column{
 				NewColumn(columnKeySelect, m.selectedText, len([]rune(m.selectedText))),
 			}, m.columns...)
+			m.columnCursorIndex++
 		} else {
 			m.columns = m.columns[1:]
+			m.columnCursorIndex--
 		}
 	}
 
@@ -119,8 +145,8 @@ func (m Model) SelectableRows(selectable bool) Model {
 
 // HighlightedRow returns the full Row that's currently highlighted by the user.
 func (m Model) HighlightedRow() Row {
-	if len(m.GetVisibleRows()) > 0 {
-		return m.GetVisibleRows()[m.rowCursorIndex]
+	if m.visibleRowCount() > 0 {
+		return m.visibleRow(m.rowCursorIndex)
 	}
 
 	// TODO: Better way to do this without pointers/nil?  Or should it be nil?
@@ -128,6 +154,9 @@ func (m Model) HighlightedRow() Row {
 }
 
 // SelectedRows returns all rows that have been set as selected by the user.
+// If a row source is set, only the selected rows on the loaded page are
+// returned, since rows on other pages aren't kept.  Use SelectedRowIDs to get
+// the selected rows from every page.
 func (m Model) SelectedRows() []Row {
 	selectedRows := []Row{}
 
@@ -140,6 +169,40 @@ func (m Model) SelectedRows() []Row {
 	return selectedRows
 }
 
+// SelectedRowIDs returns the IDs set by Row.WithID of all rows that have been
+// set as selected by the user, in the same order as SelectedRows.  Selected
+// rows without an ID are left out.  If a row source is set, this includes
+// rows selected on other pages, which come after the loaded page's rows and
+// are sorted by ID.
+func (m Model) SelectedRowIDs() []string {
+	ids := []string{}
+
+	for _, row := range m.SelectedRows() {
+		if row.userID != "" {
+			ids = append(ids, row.userID)
+		}
+	}
+
+	// The saved state of the loaded rows may be out of date
+	loaded := map[string]bool{}
+
+	walkRowTree(m.rows, func(row Row) {
+		loaded[row.userID] = true
+	})
+
+	var otherPages []string
+
+	for id, row := range m.rowSourceSavedRows {
+		if row.selected && !loaded[id] {
+			otherPages = append(otherPages, id)
+		}
+	}
+
+	sort.Strings(otherPages)
+
+	return append(ids, otherPages...)
+}
+
 // HighlightStyle sets a custom style to use when the row is being highlighted
 // by the cursor.  This should not be used with WithRowStyleFunc.  Instead, use
 // the IsHighlighted field in the style function.
@@ -188,10 +251,18 @@ func (m Model) WithStaticFooter(footer string) Model {
 }
 
 // WithPageSize enables pagination using the given page size.  This can be called
-// again at any point to resize the height of the table.
+// again at any point to resize the height of the table.  A table with a row
+// source set by WithRowSource is always paginated, so a page size of 0 uses the
+// default of 100 rows.
 func (m Model) WithPageSize(pageSize int) Model {
 	m.pageSize = pageSize
 
+	if pageSize == 0 && m.rowSource != nil {
+		m.pageSize = defaultRowSourcePageSize
+	}
+
+	m.updateDetailPageStarts()
+
 	maxPages := m.MaxPages()
 
 	if m.currentPage >= maxPages {
@@ -205,10 +276,18 @@ func (m Model) WithPageSize(pageSize int) Model {
 	return m
 }
 
-// WithNoPagination disables pagination in the table.
+// WithNoPagination disables pagination in the table.  A table with a row source
+// set by WithRowSource is always paginated, so this uses the default page size
+// of 100 rows instead.
 func (m Model) WithNoPagination() Model {
 	m.pageSize = 0
 
+	if m.rowSource != nil {
+		m.pageSize = defaultRowSourcePageSize
+	}
+
+	m.updateDetailPageStarts()
+
 	if m.minimumHeight > 0 {
 		m.recalculateHeight()
 	}
@@ -247,8 +326,9 @@ func (m Model) WithBaseStyle(style lipgloss.Style) Model {
 }
 
 // WithTargetWidth sets the total target width of the table, including borders.
-// This only takes effect when using flex columns.  When using flex columns,
-// columns will stretch to fill out to the total width given here.
+// This only takes effect when using flex, percentage, or auto columns.  When
+// using flex columns, columns will stretch to fill out to the total width
+// given here, within any minimum and maximum widths set on the columns.
 func (m Model) WithTargetWidth(totalWidth int) Model {
 	m.targetTotalWidth = totalWidth
 
@@ -313,18 +393,36 @@ func (m Model) WithCurrentPage(currentPage int) Model {
 		}
 	}
 	m.currentPage = currentPage - 1
-	m.rowCursorIndex = m.currentPage * m.pageSize
+	m.rowCursorIndex = m.pageStartIndex(m.currentPage)
 
 	return m
 }
 
 // WithColumns sets the visible columns for the table, so that columns can be
-// added/removed/resized or headers rewritten.
+// added/removed/resized or headers rewritten.  This replaces any order or
+// hidden columns set by the user or with WithColumnOrder and
+// WithHiddenColumns.
 func (m Model) WithColumns(columns []Column) Model {
+	if len(m.columns) > 0 && m.columns[0].key == columnKeySelect {
+		// The select column is re-added below, which shifts the cursor back
+		m.columnCursorIndex--
+	}
+
 	// Deep copy to avoid edits
 	m.columns = make([]Column, len(columns))
 	copy(m.columns, columns)
 
+	m.columnOrder = nil
+	m.hiddenColumns = nil
+
+	// Column aggregates for the summary row may have changed
+	if m.summaryRow {
+		m.visibleRowCacheUpdated = false
+	}
+
+	// Formatters may have changed, so auto columns are measured again
+	m.autoColumnWidths = nil
+
 	m.recalculateWidth()
 
 	if m.selectableRows {
@@ -344,7 +442,7 @@ func (m Model) WithFilterInput(input textinput.Model) Model {
 	}
 
 	m.filterTextInput = input
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m
 }
@@ -359,7 +457,7 @@ func (m Model) WithFilterInputValue(value string) Model {
 
 	m.filterTextInput.SetValue(value)
 	m.filterTextInput.Blur()
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m
 }
@@ -370,6 +468,11 @@ func (m Model) WithFilterInputValue(value string) Model {
 // if any.
 func (m Model) WithFilterFunc(shouldInclude FilterFunc) Model {
 	m.filterFunc = shouldInclude
+	m.filterKind = filterKindCustom
+
+	if shouldInclude == nil {
+		m.filterKind = filterKindContains
+	}
 
 	m.visibleRowCacheUpdated = false
 
@@ -378,7 +481,45 @@ func (m Model) WithFilterFunc(shouldInclude FilterFunc) Model {
 
 // WithFuzzyFilter enables fuzzy filtering for the table.
 func (m Model) WithFuzzyFilter() Model {
-	return m.WithFilterFunc(filterFuncFuzzy)
+	m = m.WithFilterFunc(filterFuncFuzzy)
+	m.filterKind = filterKindFuzzy
+
+	return m
+}
+
+// WithQueryFilter enables filtering with a query language for the table.  Plain
+// words and "quoted phrases" match any filterable column, /regexes/ can be used
+// in place of words, and terms can be limited to a column by key or title such
+// as name:nginx or status="Running".  Numeric columns can be compared such as
+// cpu>50 or mem<=1024.  Terms can be negated with a dash such as -status:done.
+// All terms must match, unless separated by OR to match either side.
+//
+// Invalid queries don't hide any rows, and the error is shown in the footer.
+func (m Model) WithQueryFilter() Model {
+	m = m.WithFilterFunc(filterFuncQuery)
+	m.filterKind = filterKindQuery
+
+	return m
+}
+
+// WithFilterMatchHighlight sets whether the parts of each cell that match the
+// current filter are highlighted with the filter match style.  This works with
+// the default filter, WithFuzzyFilter, and WithQueryFilter, but not with
+// custom filter functions or multiline rows.  Filters match the unformatted
+// data, so cells whose text is changed by a formatter aren't highlighted.
+func (m Model) WithFilterMatchHighlight(highlight bool) Model {
+	m.filterMatchHighlight = highlight
+
+	return m
+}
+
+// WithFilterMatchStyle sets the style used to highlight filter matches when
+// WithFilterMatchHighlight is enabled.  The style is applied on top of the
+// cell's existing style.  The default is bold and underlined.
+func (m Model) WithFilterMatchStyle(style lipgloss.Style) Model {
+	m.filterMatchStyle = style
+
+	return m
 }
 
 // WithFooterVisibility sets the visibility of the footer.
@@ -447,6 +588,8 @@ func (m Model) ScrollLeft() Model {
 func (m Model) WithMissingDataIndicator(str string) Model {
 	m.missingDataIndicator = str
 
+	m.remeasureAutoColumns()
+
 	return m
 }
 
@@ -457,20 +600,105 @@ func (m Model) WithMissingDataIndicator(str string) Model {
 func (m Model) WithMissingDataIndicatorStyled(styled StyledCell) Model {
 	m.missingDataIndicator = styled
 
+	m.remeasureAutoColumns()
+
+	return m
+}
+
+// WithMouseSupport sets whether the table responds to mouse input when focused.
+// Clicking a row highlights it, clicking the select column toggles selection,
+// clicking a header sorts by that column if WithInteractiveSorting is enabled,
+// clicking the overflow arrows scrolls horizontally, and clicking the footer
+// page indicator moves to the next page (or the previous page with a right
+// click).  The scroll wheel moves the highlighted row.
+//
+// Mouse events must be enabled in Bubble Tea, such as with
+// tea.WithMouseCellMotion().  Coordinates are expected to be relative to the
+// top left corner of the table, so if the table is not rendered at the top
+// left of the screen then the tea.MouseMsg should be offset accordingly before
+// being passed to Update.
+func (m Model) WithMouseSupport(enabled bool) Model {
+	m.mouseSupport = enabled
+
+	return m
+}
+
+// WithCellCursor sets whether a single cell in the highlighted row is
+// highlighted, which can be moved between columns with the CellLeft and
+// CellRight keys when focused.  The table scrolls horizontally as needed to
+// keep the highlighted cell in view.  Use HighlightedCell to get the current
+// cell.
+func (m Model) WithCellCursor(enabled bool) Model {
+	m.cellCursor = enabled
+
+	return m
+}
+
+// WithHighlightedCellStyle sets the style of the highlighted cell when the cell
+// cursor is enabled.  This is applied on top of the highlighted row's style.
+func (m Model) WithHighlightedCellStyle(style lipgloss.Style) Model {
+	m.highlightedCellStyle = style
+
+	return m
+}
+
+// HighlightedCell returns the row that's currently highlighted and the key of
+// the highlighted column within it.  The column key is only meaningful when
+// the cell cursor is enabled with WithCellCursor.
+func (m Model) HighlightedCell() (Row, string) {
+	column, _ := m.cursorColumn()
+
+	return m.HighlightedRow(), column.key
+}
+
+// WithInteractiveSorting sets whether the user can sort the table from the
+// keyboard when focused.  The SortColumnNext and SortColumnPrev keys choose a
+// column, which is highlighted in the header, and the SortCycle and
+// SortCycleAdd keys cycle that column between ascending, descending, and no
+// sorting.  Use WithSortIndicators to show the current sorting in the header.
+func (m Model) WithInteractiveSorting(enabled bool) Model {
+	m.interactiveSorting = enabled
+
+	return m
+}
+
+// WithSortIndicators sets the indicators appended to the header title of any
+// sorted column, such as "▲" and "▼".  If multiple columns are sorted, the
+// indicator is followed by the column's sort precedence, starting at 1.  Empty
+// strings disable the indicators, which is the default.
+func (m Model) WithSortIndicators(asc, desc string) Model {
+	m.sortIndicatorAsc = asc
+	m.sortIndicatorDesc = desc
+
+	if m.minimumHeight > 0 {
+		m.recalculateHeight()
+	}
+
+	return m
+}
+
+// WithHeaderHighlightStyle sets the style of the header of the column chosen
+// for interactive sorting.  Only shown when focused and when interactive
+// sorting is enabled.
+func (m Model) WithHeaderHighlightStyle(style lipgloss.Style) Model {
+	m.headerHighlightStyle = style
+
 	return m
 }
 
 // WithAllRowsDeselected deselects any rows that are currently selected.
 func (m Model) WithAllRowsDeselected() Model {
-	rows := m.GetVisibleRows()
-
-	for i, row := range rows {
-		if row.selected {
-			rows[i] = row.Selected(false)
+	m.rows, _ = mapRowTree(m.rows, func(row *Row) bool {
+		if !row.selected {
+			return false
 		}
-	}
 
-	m.rows = rows
+		row.selected = false
+
+		return true
+	})
+
+	m.visibleRowCacheUpdated = false
 
 	return m
 }
@@ -506,5 +734,8 @@ func (m Model) WithAdditionalFullHelpKeys(keys []key.Binding) Model {
 func (m Model) WithGlobalMetadata(metadata map[string]any) Model {
 	m.metadata = metadata
 
+	// Detail panels may change size with the metadata
+	m.updateDetailPageStarts()
+
 	return m
 }
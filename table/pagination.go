@@ -1,5 +1,11 @@
 package table
 
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
 // PageSize returns the current page size for the table, or 0 if there is no
 // pagination enabled.
 func (m *Model) PageSize() int {
@@ -14,25 +20,33 @@ func (m *Model) CurrentPage() int {
 
 // MaxPages returns the maximum number of pages that are visible.
 func (m *Model) MaxPages() int {
-	totalRows := len(m.GetVisibleRows())
+	totalRows := m.visibleRowCount()
 
 	if m.pageSize == 0 || totalRows == 0 {
 		return 1
 	}
 
+	if pageStarts := m.detailPageStarts(); pageStarts != nil {
+		return len(pageStarts)
+	}
+
 	return (totalRows-1)/m.pageSize + 1
 }
 
 // TotalRows returns the current total row count of the table.  If the table is
-// paginated, this is the total number of rows across all pages.
+// paginated, this is the total number of rows across all pages.  If rows are
+// grouped with WithGroupBy, this counts the displayed rows including group
+// headers.
 func (m *Model) TotalRows() int {
-	return len(m.GetVisibleRows())
+	return m.visibleRowCount()
 }
 
 // VisibleIndices returns the current visible rows by their 0 based index.
-// Useful for custom pagination footers.
+// Useful for custom pagination footers.  If rows are grouped with WithGroupBy,
+// these are positions in the displayed rows including group headers, the same
+// as TotalRows.
 func (m *Model) VisibleIndices() (start, end int) {
-	totalRows := len(m.GetVisibleRows())
+	totalRows := m.visibleRowCount()
 
 	if m.pageSize == 0 {
 		start = 0
@@ -41,8 +55,8 @@ func (m *Model) VisibleIndices() (start, end int) {
 		return start, end
 	}
 
-	start = m.pageSize * m.currentPage
-	end = start + m.pageSize - 1
+	start = m.pageStartIndex(m.currentPage)
+	end = m.pageStartIndex(m.currentPage+1) - 1
 
 	if end >= totalRows {
 		end = totalRows - 1
@@ -51,8 +65,75 @@ func (m *Model) VisibleIndices() (start, end int) {
 	return start, end
 }
 
+// detailPageStarts returns the index of the first row of each page if any
+// detail panels are shown, since detail panels count toward the page size and
+// so pages may have fewer rows.  Returns nil if every page has pageSize rows.
+// This is calculated along with the visible rows.
+func (m *Model) detailPageStarts() []int {
+	if m.pageSize == 0 || m.rowDetailFunc == nil || m.rowSource != nil {
+		return nil
+	}
+
+	// Recalculates the page starts if the visible rows are out of date
+	m.GetVisibleRows()
+
+	return m.detailPageStartsCache
+}
+
+// updateDetailPageStarts recalculates where each page starts for the current
+// visible rows, after the visible rows or anything that changes the size of
+// detail panels has changed.
+func (m *Model) updateDetailPageStarts() {
+	m.detailPageStartsCache = nil
+
+	if m.pageSize == 0 || m.rowDetailFunc == nil || m.rowSource != nil || !m.visibleRowCacheUpdated {
+		return
+	}
+
+	pageStarts := []int{0}
+	pageHeight := 0
+	hasDetails := false
+
+	for i, row := range m.displayRows() {
+		height := 1
+
+		if row.detailExpanded {
+			if content := m.rowDetailContent(i); content != "" {
+				height += lipgloss.Height(content)
+				hasDetails = true
+			}
+		}
+
+		// Rows always fit on an empty page, even if their details are taller
+		if pageHeight > 0 && pageHeight+height > m.pageSize {
+			pageStarts = append(pageStarts, i)
+			pageHeight = 0
+		}
+
+		pageHeight += height
+	}
+
+	if hasDetails {
+		m.detailPageStartsCache = pageStarts
+	}
+}
+
+// pageStartIndex returns the index of the first row on the given page, or the
+// total row count if there's no such page.
+func (m *Model) pageStartIndex(page int) int {
+	if pageStarts := m.detailPageStarts(); pageStarts != nil {
+		if page >= len(pageStarts) {
+			return m.visibleRowCount()
+		}
+
+		return pageStarts[page]
+	}
+
+	return page * m.pageSize
+}
+
 func (m *Model) pageDown() {
-	if m.pageSize == 0 || len(m.GetVisibleRows()) <= m.pageSize {
+	if m.pageSize == 0 || m.MaxPages() <= 1 {
 		return
 	}
 
@@ -68,11 +149,11 @@ func (m *Model) pageDown() {
 		}
 	}
 
-	m.rowCursorIndex = m.currentPage * m.pageSize
+	m.rowCursorIndex = m.pageStartIndex(m.currentPage)
 }
 
 func (m *Model) pageUp() {
-	if m.pageSize == 0 || len(m.GetVisibleRows()) <= m.pageSize {
+	if m.pageSize == 0 || m.MaxPages() <= 1 {
 		return
 	}
 
@@ -88,7 +169,7 @@ func (m *Model) pageUp() {
 		}
 	}
 
-	m.rowCursorIndex = m.currentPage * m.pageSize
+	m.rowCursorIndex = m.pageStartIndex(m.currentPage)
 }
 
 func (m *Model) pageFirst() {
@@ -98,7 +179,7 @@ func (m *Model) pageFirst() {
 
 func (m *Model) pageLast() {
 	m.currentPage = m.MaxPages() - 1
-	m.rowCursorIndex = m.currentPage * m.pageSize
+	m.rowCursorIndex = m.pageStartIndex(m.currentPage)
 }
 
 func (m *Model) expectedPageForRowIndex(rowIndex int) int {
@@ -106,6 +187,10 @@ func (m *Model) expectedPageForRowIndex(rowIndex int) int {
 		return 0
 	}
 
+	if pageStarts := m.detailPageStarts(); pageStarts != nil {
+		return sort.SearchInts(pageStarts, rowIndex+1) - 1
+	}
+
 	expectedPage := rowIndex / m.pageSize
 
 	return expectedPage
@@ -0,0 +1,92 @@
+package table
+
+import "github.com/charmbracelet/lipgloss"
+
+// WithPinnedRowsTop sets rows that are always shown at the top of the table
+// just beneath the header, such as a row for the current user.  Pinned rows
+// stay on every page and are never filtered, sorted, highlighted, or selected,
+// and they aren't returned by GetVisibleRows.  They're shown with the style set
+// by WithPinnedRowStyle.
+func (m Model) WithPinnedRowsTop(rows ...Row) Model {
+	m.pinnedRowsTop = make([]Row, len(rows))
+	copy(m.pinnedRowsTop, rows)
+
+	if m.minimumHeight > 0 {
+		m.recalculateHeight()
+	}
+
+	return m
+}
+
+// WithPinnedRowsBottom sets rows that are always shown at the bottom of the
+// table's rows, above any summary row and footer, such as a totals row.  They
+// behave the same as rows set by WithPinnedRowsTop.
+func (m Model) WithPinnedRowsBottom(rows ...Row) Model {
+	m.pinnedRowsBottom = make([]Row, len(rows))
+	copy(m.pinnedRowsBottom, rows)
+
+	if m.minimumHeight > 0 {
+		m.recalculateHeight()
+	}
+
+	return m
+}
+
+// WithPinnedRowStyle sets the style of the rows set by WithPinnedRowsTop and
+// WithPinnedRowsBottom.  Any style set on the row itself takes priority.
+func (m Model) WithPinnedRowStyle(style lipgloss.Style) Model {
+	m.pinnedRowStyle = style
+
+	return m
+}
+
+// GetPinnedRowsTop returns the rows pinned to the top of the table.  The
+// returned list is a copy and modifications will have no effect.
+func (m *Model) GetPinnedRowsTop() []Row {
+	rows := make([]Row, len(m.pinnedRowsTop))
+	copy(rows, m.pinnedRowsTop)
+
+	return rows
+}
+
+// GetPinnedRowsBottom returns the rows pinned to the bottom of the table.  The
+// returned list is a copy and modifications will have no effect.
+func (m *Model) GetPinnedRowsBottom() []Row {
+	rows := make([]Row, len(m.pinnedRowsBottom))
+	copy(rows, m.pinnedRowsBottom)
+
+	return rows
+}
+
+// hasPinnedRows returns true if any rows are pinned to the top or bottom.
+func (m *Model) hasPinnedRows() bool {
+	return len(m.pinnedRowsTop) > 0 || len(m.pinnedRowsBottom) > 0
+}
+
+// pinnedRowsHeight returns the total height of all pinned rows.
+func (m *Model) pinnedRowsHeight() int {
+	height := 0
+
+	for _, row := range m.pinnedRowsTop {
+		height += lipgloss.Height(m.renderPinnedRow(row, false))
+	}
+
+	for _, row := range m.pinnedRowsBottom {
+		height += lipgloss.Height(m.renderPinnedRow(row, false))
+	}
+
+	return height
+}
+
+// renderPinnedRow renders a pinned row, which isn't part of any tree or filter.
+func (m Model) renderPinnedRow(row Row, last bool) string {
+	pinnedModel := m
+	pinnedModel.treeRows = false
+	pinnedModel.filterMatchHighlight = false
+
+	row.depth = 0
+
+	style := row.Style.Copy().Inherit(m.pinnedRowStyle)
+
+	return pinnedModel.renderRowData(row, style, -1, last)
+}
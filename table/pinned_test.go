@@ -0,0 +1,95 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func genPinnedModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 6).WithFiltered(true),
+		NewColumn("cpu", "CPU", 5),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "b", "cpu": 2}),
+		NewRow(RowData{"name": "a", "cpu": 1}),
+		NewRow(RowData{"name": "c", "cpu": 3}),
+	}).
+		WithPinnedRowsTop(NewRow(RowData{"name": "self", "cpu": 9})).
+		WithPinnedRowsBottom(NewRow(RowData{"name": "total", "cpu": 6})).
+		WithPinnedRowStyle(lipgloss.NewStyle()).
+		WithFooterVisibility(false)
+}
+
+func TestPinnedRowsView(t *testing.T) {
+	model := genPinnedModel().SortByAsc("name")
+
+	const expectedTable = `┏━━━━━━┳━━━━━┓
+┃  Name┃  CPU┃
+┣━━━━━━╋━━━━━┫
+┃  self┃    9┃
+┃     a┃    1┃
+┃     b┃    2┃
+┃     c┃    3┃
+┃ total┃    6┃
+┗━━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestPinnedRowsAreNotData(t *testing.T) {
+	model := genPinnedModel().Filtered(true).WithFilterInputValue("zzz").Focused(true)
+
+	assert.Empty(t, model.GetVisibleRows())
+
+	const expectedTable = `┏━━━━━━┳━━━━━┓
+┃  Name┃  CPU┃
+┣━━━━━━╋━━━━━┫
+┃  self┃    9┃
+┃ total┃    6┃
+┗━━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	model = model.WithFilterInputValue("")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Equal(t, "a", model.HighlightedRow().Data["name"])
+}
+
+func TestPinnedRowsOnEveryPage(t *testing.T) {
+	model := genPinnedModel().WithPageSize(2).WithPinnedRowsBottom()
+
+	assert.Contains(t, model.View(), "self")
+
+	model = model.PageDown()
+
+	assert.Contains(t, model.View(), "self")
+	assert.Contains(t, model.View(), "c")
+	assert.Len(t, model.GetPinnedRowsTop(), 1)
+	assert.Empty(t, model.GetPinnedRowsBottom())
+}
+
+func TestPinnedRowsOnlyTopIsLast(t *testing.T) {
+	model := genPinnedModel().WithRows(nil).WithPinnedRowsBottom()
+
+	const expectedTable = `┏━━━━━━┳━━━━━┓
+┃  Name┃  CPU┃
+┣━━━━━━╋━━━━━┫
+┃  self┃    9┃
+┗━━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestPinnedRowsMinimumHeight(t *testing.T) {
+	model := genPinnedModel().WithMinimumHeight(12)
+
+	assert.Equal(t, 12, lipgloss.Height(model.View()))
+
+	model = model.WithSummaryRow(true)
+
+	assert.Equal(t, 12, lipgloss.Height(model.View()))
+}
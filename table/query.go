@@ -1,5 +1,7 @@
 package table
 
+import "fmt"
+
 // GetColumnSorting returns the current sorting rules for the table as a list of
 // SortColumns, which are applied from first to last.  This means that data will
 // be grouped by the later elements in the list.  The returned list is a copy
@@ -37,29 +39,112 @@ func (m *Model) GetCurrentFilter() string {
 	return m.filterTextInput.Value()
 }
 
-// GetVisibleRows returns sorted and filtered rows.
+// GetVisibleRows returns sorted and filtered rows.  If a row source is set, only
+// the rows that are currently loaded are returned.
 func (m *Model) GetVisibleRows() []Row {
+	if m.rowSource != nil {
+		rows := make([]Row, len(m.rows))
+		copy(rows, m.rows)
+
+		return rows
+	}
+
 	if m.visibleRowCacheUpdated {
 		return m.visibleRowCache
 	}
 
-	rows := make([]Row, len(m.rows))
-	copy(rows, m.rows)
-	if m.filtered {
-		rows = m.getFilteredRows(rows)
-	}
-	rows = getSortedRows(m.sortOrder, rows)
+	rows := m.calculateVisibleRows(m.rows, m.appliedFilter(), m.appliedSortOrder())
 
+	m.setVisibleRowCache(rows)
+
+	return rows
+}
+
+// setVisibleRowCache sets the sorted and filtered rows, along with the groups,
+// summary values, and detail panel pages that are shown for them.
+func (m *Model) setVisibleRowCache(rows []Row) {
 	m.visibleRowCache = rows
 	m.visibleRowCacheUpdated = true
 
+	if m.isGrouped() {
+		m.groupedRowCache, m.groupedRowIndexes, m.groupedDisplayIndexes = m.groupRows(rows)
+	} else {
+		m.groupedRowCache = nil
+		m.groupedRowIndexes = nil
+		m.groupedDisplayIndexes = nil
+	}
+
+	if m.summaryRow {
+		m.summaryValueCache = m.calculateSummaryValues(rows)
+	} else {
+		m.summaryValueCache = nil
+	}
+
+	m.updateDetailPageStarts()
+}
+
+// calculateVisibleRows returns a sorted and filtered copy of the given rows.
+func (m Model) calculateVisibleRows(allRows []Row, filter string, sortOrder []SortColumn) []Row {
+	m = m.withParsedFilterQuery(filter)
+
+	if rowsHaveChildren(allRows) {
+		return m.visibleRowTree(allRows, filter, sortOrder, 0)
+	}
+
+	rows := make([]Row, len(allRows))
+	copy(rows, allRows)
+	if m.filtered {
+		rows = m.filterRows(rows, filter)
+	}
+	rows = getSortedRows(sortOrder, m.columnSorts(), rows)
+
 	return rows
 }
 
+// queryKey identifies the current sorting and filtering, so that results
+// calculated for a different query can be recognized.
+func (m *Model) queryKey() string {
+	filter := ""
+
+	if m.filtered {
+		filter = m.filterTextInput.Value()
+	}
+
+	return fmt.Sprintf("%v|%q", m.sortOrder, filter)
+}
+
+// visibleRowCount returns the total number of visible rows, including any
+// that haven't been loaded from the row source yet.
+func (m *Model) visibleRowCount() int {
+	if m.rowSource != nil {
+		// This may be from a previous query until the new count is loaded
+		return m.rowSourceCount
+	}
+
+	return len(m.displayRows())
+}
+
+// visibleRow returns the visible row at the given index, or a loading
+// placeholder if it hasn't been loaded from the row source yet.
+func (m *Model) visibleRow(index int) Row {
+	if m.rowSource != nil {
+		loadedIndex := index - m.rowSourceOffset
+
+		if !m.isRowSourceLoaded() || loadedIndex < 0 || loadedIndex >= len(m.rows) {
+			return m.loadingPlaceholderRow()
+		}
+
+		return m.rows[loadedIndex]
+	}
+
+	return m.displayRows()[index]
+}
+
 // GetHighlightedRowIndex returns the index of the Row that's currently highlighted
-// by the user.
+// by the user.  If rows are grouped with WithGroupBy, this is the index in
+// GetVisibleRows, or -1 if a group header is highlighted.
 func (m *Model) GetHighlightedRowIndex() int {
-	return m.rowCursorIndex
+	return m.rowIndex(m.rowCursorIndex)
 }
 
 // GetFocused returns whether or not the table is focused and is receiving inputs.
@@ -94,3 +179,13 @@ func (m *Model) GetFooterVisibility() bool {
 func (m *Model) GetPaginationWrapping() bool {
 	return m.paginationWrapping
 }
+
+// GetMouseSupport returns true if the table responds to mouse input.
+func (m *Model) GetMouseSupport() bool {
+	return m.mouseSupport
+}
+
+// GetIsEditingCell returns true if the user is currently editing a cell.
+func (m *Model) GetIsEditingCell() bool {
+	return m.cellEditor != nil
+}
@@ -25,6 +25,28 @@ type Row struct {
 
 	// id is an internal unique ID to match rows after they're copied
 	id uint32
+
+	// userID is an optional ID supplied by the user to match rows across
+	// different calls to WithRows
+	userID string
+
+	// children are shown beneath the row when it's expanded
+	children []Row
+	expanded bool
+
+	// detailExpanded shows the row's detail panel beneath it
+	detailExpanded bool
+
+	// depth is how deeply nested the row is, only set in visible rows, where
+	// group headers are -1
+	depth int
+
+	// groupHeader is set if this is a group header rather than data
+	groupHeader *rowGroupHeader
+
+	// explicitState is which interaction states were set on the row directly,
+	// which take priority over the state of a previous row with the same ID
+	explicitState rowState
 }
 
 var lastRowID uint32 = 1
@@ -46,6 +68,23 @@ func NewRow(data RowData) Row {
 	return row
 }
 
+// WithID sets a user-defined ID for the row, such as a database key or a pod
+// name.  Rows with an ID keep their selection and highlight when the table's
+// rows are replaced with WithRows, as long as the new rows use the same IDs,
+// unless the new row's selection is set with Selected.  IDs should be unique
+// within a table.
+func (r Row) WithID(id string) Row {
+	r.userID = id
+
+	return r
+}
+
+// ID returns the user-defined ID of the row, or an empty string if none was
+// set with WithID.
+func (r Row) ID() string {
+	return r.userID
+}
+
 // WithStyle uses the given style for the text in the row.
 func (r Row) WithStyle(style lipgloss.Style) Row {
 	r.Style = style.Copy()
@@ -59,29 +98,33 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 
 	var str string
 
-	switch column.key {
-	case columnKeySelect:
+	// Which characters of the cell match the current filter, if any
+	var matchMask []bool
+
+	switch {
+	case m.isEditingCell(row, column):
+		str = m.cellEditor.View()
+	case column.key == columnKeySelect:
 		if row.selected {
 			str = m.selectedText
 		} else {
 			str = m.unselectedText
 		}
-	case columnKeyOverflowRight:
+	case column.key == columnKeyOverflowRight:
 		cellStyle = cellStyle.Align(lipgloss.Right)
 		str = ">"
-	case columnKeyOverflowLeft:
+	case column.key == columnKeyOverflowLeft:
 		str = "<"
 	default:
-		fmtString := "%v"
+		formatData := func(data any) string {
+			return fmt.Sprintf("%v", data)
+		}
 
 		var data any
 
 		if entry, exists := row.Data[column.key]; exists {
 			data = entry
-
-			if column.fmtString != "" {
-				fmtString = column.fmtString
-			}
+			formatData = column.formatData
 		} else if m.missingDataIndicator != nil {
 			data = m.missingDataIndicator
 		} else {
@@ -90,7 +133,7 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 
 		switch entry := data.(type) {
 		case StyledCell:
-			str = fmt.Sprintf(fmtString, entry.Data)
+			str = formatData(entry.Data)
 
 			if entry.StyleFunc != nil {
 				cellStyle = entry.StyleFunc(StyledCellFuncInput{
@@ -103,13 +146,31 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 				cellStyle = entry.Style.Copy().Inherit(cellStyle)
 			}
 		default:
-			str = fmt.Sprintf(fmtString, entry)
+			str = formatData(entry)
+		}
+
+		if _, exists := row.Data[column.key]; exists {
+			matchMask = m.filterMatchMask(row, column, str)
+		}
+
+		if m.treeRows && column.key == m.treeColumnKey() {
+			prefix := treePrefix(row)
+			str = prefix + str
+
+			// Indentation would be lost if aligned any other way
+			cellStyle = cellStyle.Align(lipgloss.Left)
+
+			if matchMask != nil {
+				matchMask = append(make([]bool, len([]rune(prefix))), matchMask...)
+			}
 		}
 	}
 
 	if m.multiline {
 		str = wordwrap.String(str, column.width)
 		cellStyle = cellStyle.Align(lipgloss.Top)
+	} else if matchMask != nil {
+		str = m.renderFilterMatches(str, matchMask, column.width, cellStyle)
 	} else {
 		str = limitStr(str, column.width)
 	}
@@ -120,15 +181,19 @@ func (m Model) renderRowColumnData(row Row, column Column, rowStyle lipgloss.Sty
 	return cellStr
 }
 
-func (m Model) renderRow(rowIndex int, last bool) string {
-	row := m.GetVisibleRows()[rowIndex]
-	highlighted := rowIndex == m.rowCursorIndex
+func (m Model) renderRow(displayIndex int, last bool) string {
+	row := m.visibleRow(displayIndex)
+	highlighted := displayIndex == m.rowCursorIndex
+
+	if row.groupHeader != nil {
+		return m.renderGroupHeader(row.groupHeader, highlighted, last)
+	}
 
 	rowStyle := row.Style.Copy()
 
 	if m.rowStyleFunc != nil {
 		styleResult := m.rowStyleFunc(RowStyleFuncInput{
-			Index:         rowIndex,
+			Index:         m.rowIndex(displayIndex),
 			Row:           row,
 			IsHighlighted: m.focused && highlighted,
 		})
@@ -138,25 +203,69 @@ func (m Model) renderRow(rowIndex int, last bool) string {
 		rowStyle = rowStyle.Inherit(m.highlightStyle)
 	}
 
-	return m.renderRowData(row, rowStyle, last)
+	highlightedColumnIndex := -1
+
+	if m.focused && highlighted && m.cellCursor {
+		highlightedColumnIndex = m.columnCursorIndex
+	}
+
+	return m.renderRowData(row, rowStyle, highlightedColumnIndex, last)
 }
 
 func (m Model) renderBlankRow(last bool) string {
-	return m.renderRowData(NewRow(nil), lipgloss.NewStyle(), last)
+	return m.renderRowData(NewRow(nil), lipgloss.NewStyle(), -1, last)
+}
+
+// fullRowWidth returns the width inside the outer borders of a row that spans
+// every column, such as a group header.
+func (m Model) fullRowWidth() int {
+	const borderAdjustment = 2
+
+	return lipgloss.Width(m.renderBlankRow(false)) - borderAdjustment
+}
+
+// fullRowBorderStyle returns the border style for a row that spans every
+// column, such as a group header.
+func (m Model) fullRowBorderStyle(last bool) lipgloss.Style {
+	if !last {
+		return m.border.styleSingleColumnInner
+	}
+
+	if m.hasFooter() {
+		return m.border.styleBothWithFooter(m.border.styleSingleColumnBottom)
+	}
+
+	return m.border.styleSingleColumnBottom
 }
 
 // This is long and could use some refactoring in the future, but not quite sure
 // how to pick it apart yet.
 //
 //nolint:funlen, cyclop
-func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string {
+func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, highlightedColumnIndex int, last bool) string {
+	stylesInner, stylesLast := m.styleRows()
+
+	if last {
+		return m.renderRowDataWithBorders(row, rowStyle, highlightedColumnIndex, stylesLast)
+	}
+
+	return m.renderRowDataWithBorders(row, rowStyle, highlightedColumnIndex, stylesInner)
+}
+
+// renderRowDataWithBorders renders the row's cells with the given borders.
+//
+//nolint:funlen, cyclop
+func (m Model) renderRowDataWithBorders(
+	row Row,
+	rowStyle lipgloss.Style,
+	highlightedColumnIndex int,
+	rowStyles borderStyleRow,
+) string {
 	numColumns := len(m.columns)
 
 	columnStrings := []string{}
 	totalRenderedWidth := 0
 
-	stylesInner, stylesLast := m.styleRows()
-
 	maxCellHeight := 1
 	if m.multiline {
 		for _, column := range m.columns {
@@ -167,13 +276,7 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 
 	for columnIndex, column := range m.columns {
 		var borderStyle lipgloss.Style
-		var rowStyles borderStyleRow
 
-		if !last {
-			rowStyles = stylesInner
-		} else {
-			rowStyles = stylesLast
-		}
 		rowStyle = rowStyle.Copy().Height(maxCellHeight)
 
 		if m.horizontalScrollOffsetCol > 0 && columnIndex == m.horizontalScrollFreezeColumnsCount {
@@ -205,7 +308,13 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 			borderStyle = rowStyles.right
 		}
 
-		cellStr := m.renderRowColumnData(row, column, rowStyle, borderStyle)
+		cellRowStyle := rowStyle
+
+		if columnIndex == highlightedColumnIndex {
+			cellRowStyle = m.highlightedCellStyle.Copy().Inherit(rowStyle)
+		}
+
+		cellStr := m.renderRowColumnData(row, column, cellRowStyle, borderStyle)
 
 		if m.maxTotalWidth != 0 {
 			renderedWidth := lipgloss.Width(cellStr)
@@ -247,6 +356,7 @@ func (m Model) renderRowData(row Row, rowStyle lipgloss.Style, last bool) string
 // The old row is not changed in-place.
 func (r Row) Selected(selected bool) Row {
 	r.selected = selected
+	r.explicitState |= rowStateSelected
 
 	return r
 }
@@ -0,0 +1,181 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RowDetailFuncInput is the input to the RowDetailFunc.
+type RowDetailFuncInput struct {
+	// Row is the row whose details are being shown.
+	Row Row
+
+	// RowIndex is the index of the row, starting at 0.
+	RowIndex int
+
+	// Width is the width available inside the table's borders.  Lines that
+	// are any longer are truncated.
+	Width int
+
+	// GlobalMetadata is the global table metadata that's been set by WithGlobalMetadata
+	GlobalMetadata map[string]any
+}
+
+// RowDetailFunc returns the rendered content of a row's detail panel, which
+// may span many lines.  Returning an empty string shows no panel.
+type RowDetailFunc func(input RowDetailFuncInput) string
+
+// WithRowDetail sets a function that renders a detail panel shown beneath a
+// row across the full width of the table, such as a pretty-printed JSON
+// payload or the View of a nested table.  Rows show their detail panel when
+// expanded with the RowDetailToggle key or Row.WithDetailExpanded.  Each line
+// of a detail panel counts as a row toward the page size and the minimum
+// height of the table, so pages with detail panels have fewer rows.  A row
+// whose detail panel is taller than the page size gets a page to itself.
+// Detail panels aren't supported by row sources.  Set to nil to remove all
+// detail panels.
+func (m Model) WithRowDetail(detailFunc RowDetailFunc) Model {
+	m.rowDetailFunc = detailFunc
+
+	m.updateDetailPageStarts()
+
+	return m
+}
+
+// WithRowDetailStyle sets the style of the detail panels set by WithRowDetail.
+func (m Model) WithRowDetailStyle(style lipgloss.Style) Model {
+	m.rowDetailStyle = style
+
+	return m
+}
+
+// WithDetailExpanded sets whether the row's detail panel is shown when a
+// detail function is set with WithRowDetail.
+func (r Row) WithDetailExpanded(expanded bool) Row {
+	r.detailExpanded = expanded
+	r.explicitState |= rowStateDetailExpanded
+
+	return r
+}
+
+// IsDetailExpanded returns true if the row's detail panel is shown.
+func (r Row) IsDetailExpanded() bool {
+	return r.detailExpanded
+}
+
+// ExpandRowDetailsByID shows the detail panels of all rows with any of the
+// given user-defined IDs set by Row.WithID.
+func (m Model) ExpandRowDetailsByID(ids ...string) Model {
+	return m.withRowDetailsExpandedByID(ids, true)
+}
+
+// CollapseRowDetailsByID hides the detail panels of all rows with any of the
+// given user-defined IDs set by Row.WithID.
+func (m Model) CollapseRowDetailsByID(ids ...string) Model {
+	return m.withRowDetailsExpandedByID(ids, false)
+}
+
+func (m Model) withRowDetailsExpandedByID(ids []string, expanded bool) Model {
+	toChange := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		toChange[id] = true
+	}
+
+	var changed bool
+
+	m.rows, changed = mapRowTree(m.rows, func(row *Row) bool {
+		if row.userID == "" || !toChange[row.userID] || row.detailExpanded == expanded {
+			return false
+		}
+
+		row.detailExpanded = expanded
+
+		return true
+	})
+
+	if changed {
+		m.visibleRowCacheUpdated = false
+	}
+
+	return m
+}
+
+// toggleHighlightedRowDetail shows or hides the detail panel of the
+// highlighted row.
+func (m *Model) toggleHighlightedRowDetail() {
+	if m.rowDetailFunc == nil || m.rowSource != nil || m.visibleRowCount() == 0 {
+		return
+	}
+
+	highlighted := m.visibleRow(m.rowCursorIndex)
+
+	if highlighted.groupHeader != nil {
+		return
+	}
+
+	expanded := !highlighted.detailExpanded
+
+	var changed bool
+
+	m.rows, changed = mapRowTree(m.rows, func(row *Row) bool {
+		if row.id != highlighted.id {
+			return false
+		}
+
+		row.detailExpanded = expanded
+
+		return true
+	})
+
+	if !changed {
+		return
+	}
+
+	m.visibleRowCacheUpdated = false
+
+	m.appendUserEvent(UserEventRowDetailToggled{
+		RowIndex:   m.rowIndex(m.rowCursorIndex),
+		IsExpanded: expanded,
+	})
+}
+
+// rowDetailContent returns the content of the detail panel for the row at the
+// given display index, or an empty string if it has no panel.
+func (m Model) rowDetailContent(displayIndex int) string {
+	if m.rowDetailFunc == nil || m.rowSource != nil {
+		return ""
+	}
+
+	row := m.visibleRow(displayIndex)
+
+	if !row.detailExpanded || row.groupHeader != nil {
+		return ""
+	}
+
+	return m.rowDetailFunc(RowDetailFuncInput{
+		Row:            row,
+		RowIndex:       m.rowIndex(displayIndex),
+		Width:          m.fullRowWidth(),
+		GlobalMetadata: m.metadata,
+	})
+}
+
+// renderRowDetail renders a detail panel across the full width of the table.
+func (m Model) renderRowDetail(content string, last bool) string {
+	width := m.fullRowWidth()
+	lines := strings.Split(content, "\n")
+
+	for i, line := range lines {
+		lines[i] = limitStr(line, width)
+	}
+
+	style := m.rowDetailStyle.Copy().
+		Inherit(m.baseStyle).
+		Inherit(m.fullRowBorderStyle(last)).
+		Align(lipgloss.Left).
+		Width(width)
+
+	return style.Render(strings.Join(lines, "\n"))
+}
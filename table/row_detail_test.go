@@ -0,0 +1,203 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func genRowDetailModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 6),
+		NewColumn("level", "Level", 6),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a", "level": "info", "msg": "started"}).WithID("a"),
+		NewRow(RowData{"name": "b", "level": "warn", "msg": "slow\nretrying"}).WithID("b"),
+		NewRow(RowData{"name": "c", "level": "info", "msg": "done"}).WithID("c"),
+	}).WithRowDetail(func(input RowDetailFuncInput) string {
+		return fmt.Sprintf("%v", input.Row.Data["msg"])
+	}).WithFooterVisibility(false).Focused(true)
+}
+
+func TestRowDetailToggleKey(t *testing.T) {
+	model := genRowDetailModel().WithHighlightedRow(1)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	assert.True(t, model.HighlightedRow().IsDetailExpanded())
+	assert.Equal(t, []UserEvent{UserEventRowDetailToggled{RowIndex: 1, IsExpanded: true}}, model.GetLastUpdateUserEvents())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	assert.False(t, model.HighlightedRow().IsDetailExpanded())
+	assert.Equal(t, []UserEvent{UserEventRowDetailToggled{RowIndex: 1, IsExpanded: false}}, model.GetLastUpdateUserEvents())
+}
+
+func TestRowDetailToggleKeyWithoutDetailFunc(t *testing.T) {
+	model := genRowDetailModel().WithRowDetail(nil)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	assert.False(t, model.HighlightedRow().IsDetailExpanded())
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+}
+
+func TestRowDetailView(t *testing.T) {
+	model := genRowDetailModel().ExpandRowDetailsByID("b", "c")
+
+	const expectedTable = `┏━━━━━━┳━━━━━━┓
+┃  Name┃ Level┃
+┣━━━━━━╋━━━━━━┫
+┃     a┃  info┃
+┃     b┃  warn┃
+┃slow         ┃
+┃retrying     ┃
+┃     c┃  info┃
+┃done         ┃
+┗━━━━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+
+	model = model.CollapseRowDetailsByID("b", "c")
+
+	const expectedCollapsed = `┏━━━━━━┳━━━━━━┓
+┃  Name┃ Level┃
+┣━━━━━━╋━━━━━━┫
+┃     a┃  info┃
+┃     b┃  warn┃
+┃     c┃  info┃
+┗━━━━━━┻━━━━━━┛`
+
+	assert.Equal(t, expectedCollapsed, model.View())
+}
+
+func TestRowDetailTruncatesLongLines(t *testing.T) {
+	model := genRowDetailModel().WithRowDetail(func(input RowDetailFuncInput) string {
+		assert.Equal(t, 13, input.Width)
+
+		return "a very long line of details"
+	}).ExpandRowDetailsByID("c").WithRowDetailStyle(lipgloss.NewStyle())
+
+	const expectedTable = `┏━━━━━━┳━━━━━━┓
+┃  Name┃ Level┃
+┣━━━━━━╋━━━━━━┫
+┃     a┃  info┃
+┃     b┃  warn┃
+┃     c┃  info┃
+┃a very long …┃
+┗━━━━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestRowDetailMinimumHeight(t *testing.T) {
+	model := genRowDetailModel().WithMinimumHeight(12)
+
+	assert.Equal(t, 12, lipgloss.Height(model.View()))
+
+	model = model.ExpandRowDetailsByID("b")
+
+	assert.Equal(t, 12, lipgloss.Height(model.View()))
+
+	// Taller than the minimum height grows the table
+	model = model.ExpandRowDetailsByID("a", "c").WithMinimumHeight(8)
+
+	assert.Equal(t, 11, lipgloss.Height(model.View()))
+}
+
+func TestRowDetailPagination(t *testing.T) {
+	model := genRowDetailModel().WithPageSize(2).ExpandRowDetailsByID("c")
+
+	assert.Equal(t, 1, model.CurrentPage())
+	assert.NotContains(t, model.View(), "done")
+
+	model = model.PageDown()
+
+	assert.Contains(t, model.View(), "done")
+}
+
+func TestRowDetailCountsTowardPageSize(t *testing.T) {
+	model := genRowDetailModel().WithPageSize(4)
+
+	assert.Equal(t, 1, model.MaxPages())
+
+	// b and its two line detail panel fill the first page
+	model = model.ExpandRowDetailsByID("b")
+
+	assert.Equal(t, 2, model.MaxPages())
+	assert.Equal(t, []int{0, 1}, visibleIndicesSlice(model))
+
+	model = model.PageDown()
+
+	assert.Equal(t, []int{2, 2}, visibleIndicesSlice(model))
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	// A panel taller than the page still gets its row on a page of its own
+	model = model.WithPageSize(2).WithCurrentPage(1)
+
+	assert.Equal(t, 3, model.MaxPages())
+	assert.Equal(t, []int{1, 1}, visibleIndicesSlice(model.WithCurrentPage(2)))
+	assert.Equal(t, 2, model.expectedPageForRowIndex(2))
+}
+
+func visibleIndicesSlice(model Model) []int {
+	start, end := model.VisibleIndices()
+
+	return []int{start, end}
+}
+
+func TestRowDetailKeptThroughWithRows(t *testing.T) {
+	model := genRowDetailModel().ExpandRowDetailsByID("b")
+
+	model = model.WithRows([]Row{
+		NewRow(RowData{"name": "b", "level": "error", "msg": "failed"}).WithID("b"),
+	})
+
+	assert.True(t, model.GetVisibleRows()[0].IsDetailExpanded())
+	assert.Contains(t, model.View(), "failed")
+}
+
+func TestRowDetailPagesCalculatedOnlyWhenRowsChange(t *testing.T) {
+	const numRows = 100
+
+	rows := make([]Row, numRows)
+
+	for i := range rows {
+		rows[i] = NewRow(RowData{"name": fmt.Sprintf("%d", i)}).WithDetailExpanded(true)
+	}
+
+	calls := 0
+
+	model := New([]Column{NewColumn("name", "Name", 6)}).
+		WithRows(rows).
+		WithRowDetail(func(input RowDetailFuncInput) string {
+			calls++
+
+			return "detail"
+		}).
+		WithPageSize(4).
+		Focused(true)
+
+	assert.Equal(t, numRows/2, model.MaxPages())
+
+	calls = 0
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	assert.Equal(t, 0, calls, "Moving around shouldn't measure every detail panel again")
+	assert.Equal(t, 3, model.CurrentPage())
+
+	// Collapsing panels changes where pages start
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	assert.Equal(t, 5, model.GetHighlightedRowIndex())
+	assert.Equal(t, []int{4, 6}, visibleIndicesSlice(model))
+	assert.Equal(t, numRows/2, model.MaxPages())
+}
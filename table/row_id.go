@@ -0,0 +1,151 @@
+package table
+
+// rowState is a set of interaction states of a row.
+type rowState uint8
+
+const (
+	rowStateSelected rowState = 1 << iota
+	rowStateExpanded
+	rowStateDetailExpanded
+)
+
+// preserveRowState copies interaction state such as selection from the
+// current rows to any new rows with a matching user-defined ID, including any
+// child rows.  Rows in savedRows are used for IDs that aren't in the current
+// rows.  The new rows are modified in place.
+func (m *Model) preserveRowState(newRows []Row, savedRows map[string]Row) {
+	previousRows := make(map[string]Row)
+
+	walkRowTree(m.rows, func(row Row) {
+		if row.userID == "" {
+			return
+		}
+
+		if _, exists := previousRows[row.userID]; !exists {
+			previousRows[row.userID] = row
+		}
+	})
+
+	if len(previousRows) == 0 && len(savedRows) == 0 {
+		return
+	}
+
+	updated, _ := mapRowTree(newRows, func(row *Row) bool {
+		previous, exists := previousRows[row.userID]
+
+		if !exists {
+			previous, exists = savedRows[row.userID]
+		}
+
+		if row.userID == "" || !exists {
+			return false
+		}
+
+		*row = row.withStateFrom(previous)
+
+		return true
+	})
+
+	copy(newRows, updated)
+}
+
+// withStateFrom returns a copy of the row with any interaction state taken
+// from the given previous version of the row, other than states that were set
+// explicitly on the new row.
+func (r Row) withStateFrom(previous Row) Row {
+	// Keep the internal ID so that anything tracking the row continues to work
+	r.id = previous.id
+
+	if r.explicitState&rowStateSelected == 0 {
+		r.selected = previous.selected
+	}
+
+	if r.explicitState&rowStateExpanded == 0 {
+		r.expanded = previous.expanded
+	}
+
+	if r.explicitState&rowStateDetailExpanded == 0 {
+		r.detailExpanded = previous.detailExpanded
+	}
+
+	return r
+}
+
+// displayIndexOfRowID returns the index of the displayed row with the given
+// user-defined ID, or -1 if it's not displayed.
+func (m *Model) displayIndexOfRowID(id string) int {
+	if id == "" {
+		return -1
+	}
+
+	for i, row := range m.displayRows() {
+		if row.userID == id {
+			if m.rowSource != nil {
+				return i + m.rowSourceOffset
+			}
+
+			return i
+		}
+	}
+
+	return -1
+}
+
+// RowByID returns the row with the given user-defined ID set by Row.WithID.
+// Returns false if no such row exists.  Rows that are hidden by a filter are
+// still returned, as are child rows that aren't expanded.
+func (m Model) RowByID(id string) (Row, bool) {
+	var (
+		found  Row
+		exists bool
+	)
+
+	walkRowTree(m.rows, func(row Row) {
+		if !exists && row.userID != "" && row.userID == id {
+			found = row
+			exists = true
+		}
+	})
+
+	return found, exists
+}
+
+// SelectRowsByID selects all rows with any of the given user-defined IDs set
+// by Row.WithID.  Any other selected rows remain selected.
+func (m Model) SelectRowsByID(ids ...string) Model {
+	toSelect := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		toSelect[id] = true
+	}
+
+	m.rows, _ = mapRowTree(m.rows, func(row *Row) bool {
+		if row.userID == "" || !toSelect[row.userID] || row.selected {
+			return false
+		}
+
+		row.selected = true
+
+		return true
+	})
+
+	m.visibleRowCacheUpdated = false
+
+	return m
+}
+
+// HighlightRowByID highlights the row with the given user-defined ID set by
+// Row.WithID, moving to its page if paginated.  If the row doesn't exist or
+// is hidden by a filter, the highlight does not change.
+func (m Model) HighlightRowByID(id string) Model {
+	index := m.displayIndexOfRowID(id)
+
+	if index == -1 {
+		return m
+	}
+
+	m.rowCursorIndex = index
+	m.currentPage = m.expectedPageForRowIndex(index)
+
+	return m
+}
@@ -0,0 +1,166 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genPodRows(names ...string) []Row {
+	rows := []Row{}
+
+	for _, name := range names {
+		rows = append(rows, NewRow(RowData{"name": name}).WithID("pod-"+name))
+	}
+
+	return rows
+}
+
+func genRowIDModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 5).WithFiltered(true),
+	}).WithRows(genPodRows("a", "b", "c")).SelectableRows(true).Focused(true)
+}
+
+func TestRowWithID(t *testing.T) {
+	row := NewRow(RowData{})
+
+	assert.Equal(t, "", row.ID())
+
+	withID := row.WithID("abc")
+
+	assert.Equal(t, "abc", withID.ID())
+	assert.Equal(t, "", row.ID(), "Should not modify the original row")
+}
+
+func TestWithRowsPreservesSelectionByID(t *testing.T) {
+	model := genRowIDModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Len(t, model.SelectedRows(), 1)
+
+	// Fresh data where b has moved and a new row was added
+	model = model.WithRows(genPodRows("b", "a", "d", "c"))
+
+	selected := model.SelectedRows()
+
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "pod-b", selected[0].ID())
+}
+
+func TestWithRowsPreservesHighlightByID(t *testing.T) {
+	model := genRowIDModel().WithPageSize(2)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Equal(t, "pod-b", model.HighlightedRow().ID())
+
+	model = model.WithRows(genPodRows("x", "y", "z", "b"))
+
+	assert.Equal(t, "pod-b", model.HighlightedRow().ID())
+	assert.Equal(t, 3, model.GetHighlightedRowIndex())
+	assert.Equal(t, 2, model.CurrentPage())
+
+	// If the highlighted row disappears, fall back to the existing behavior
+	// of going to the start of the last page
+	model = model.WithRows(genPodRows("x", "y"))
+
+	assert.Equal(t, "pod-x", model.HighlightedRow().ID())
+}
+
+func TestWithRowsExplicitSelectionReplacesPrevious(t *testing.T) {
+	model := genRowIDModel().SelectRowsByID("pod-a", "pod-b")
+
+	rows := genPodRows("a", "b", "c")
+	rows[0] = rows[0].Selected(false)
+	rows[2] = rows[2].Selected(true)
+
+	model = model.WithRows(rows)
+
+	selected := model.SelectedRows()
+
+	assert.Len(t, selected, 2)
+	assert.Equal(t, "pod-b", selected[0].ID(), "Should keep the selection that wasn't set explicitly")
+	assert.Equal(t, "pod-c", selected[1].ID())
+}
+
+func TestWithRowsWithoutIDsDoesNotPreserveSelection(t *testing.T) {
+	model := New([]Column{NewColumn("name", "Name", 5)}).
+		WithRows([]Row{NewRow(RowData{"name": "a"})}).
+		SelectableRows(true).
+		Focused(true)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Len(t, model.SelectedRows(), 1)
+
+	model = model.WithRows([]Row{NewRow(RowData{"name": "a"})})
+
+	assert.Len(t, model.SelectedRows(), 0)
+}
+
+func TestWithRowsDoesNotModifyInput(t *testing.T) {
+	model := genRowIDModel().SelectRowsByID("pod-a")
+
+	newRows := genPodRows("a")
+
+	model = model.WithRows(newRows)
+
+	assert.Len(t, model.SelectedRows(), 1)
+	assert.False(t, newRows[0].selected)
+}
+
+func TestRowByID(t *testing.T) {
+	model := genRowIDModel().Filtered(true).WithFilterInputValue("a")
+
+	row, found := model.RowByID("pod-b")
+
+	assert.True(t, found, "Should find rows hidden by the filter")
+	assert.Equal(t, "b", row.Data["name"])
+
+	_, found = model.RowByID("pod-missing")
+
+	assert.False(t, found)
+
+	_, found = model.RowByID("")
+
+	assert.False(t, found, "Should not match rows without an ID")
+}
+
+func TestSelectRowsByID(t *testing.T) {
+	rows := genPodRows("a", "b", "c")
+	model := New([]Column{NewColumn("name", "Name", 5)}).WithRows(rows)
+
+	model = model.SelectRowsByID("pod-a", "pod-c", "pod-missing")
+
+	selected := model.SelectedRows()
+
+	assert.Len(t, selected, 2)
+	assert.Equal(t, "pod-a", selected[0].ID())
+	assert.Equal(t, "pod-c", selected[1].ID())
+
+	model = model.SelectRowsByID("pod-b")
+
+	assert.Len(t, model.SelectedRows(), 3, "Should keep existing selections")
+	assert.False(t, rows[0].selected, "Should not modify the original rows")
+}
+
+func TestHighlightRowByID(t *testing.T) {
+	model := genRowIDModel().WithPageSize(2)
+
+	model = model.HighlightRowByID("pod-c")
+
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+	assert.Equal(t, 2, model.CurrentPage())
+
+	model = model.HighlightRowByID("pod-missing")
+
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+
+	model = model.Filtered(true).WithFilterInputValue("a").HighlightRowByID("pod-b")
+
+	assert.Equal(t, "pod-a", model.HighlightedRow().ID(), "Should not highlight filtered rows")
+}
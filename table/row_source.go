@@ -0,0 +1,396 @@
+package table
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RowSource provides rows on demand for data sets that are too large to hold
+// in memory, such as the results of a database query or a very large file.
+// The table only fetches the rows it needs to show, which is the current page.
+//
+// Fetching is done asynchronously through a tea.Cmd, so both methods may block.
+// Only the rows on the loaded page are held, so rows need an ID set with
+// Row.WithID for their selection to be kept when moving between pages.
+// SelectedRows only returns the loaded page's selected rows, while
+// SelectedRowIDs returns the IDs of the selected rows on every page.
+type RowSource interface {
+	// RowCount returns the total number of rows available.
+	RowCount() (int, error)
+
+	// FetchRows returns up to limit rows starting at the given offset.
+	FetchRows(offset, limit int) ([]Row, error)
+}
+
+// SortableRowSource is a RowSource that can sort its own rows, such as with an
+// ORDER BY clause.  If the table's row source doesn't implement this, sorting
+// has no effect on the rows.
+type SortableRowSource interface {
+	RowSource
+
+	// WithSort returns a source that provides rows sorted by the given
+	// columns.  The first column is the primary sort, as in GetColumnSorting.
+	// To also support filtering, the returned source should implement
+	// FilterableRowSource.
+	WithSort(sortColumns []SortColumn) RowSource
+}
+
+// FilterableRowSource is a RowSource that can filter its own rows, such as with
+// a WHERE clause.  If the table's row source doesn't implement this, filtering
+// has no effect on the rows.
+type FilterableRowSource interface {
+	RowSource
+
+	// WithFilter returns a source that only provides rows that match the
+	// given filter text from the table's filter input.
+	WithFilter(filter string) RowSource
+}
+
+// growingRowSource is a RowSource whose row count can grow as rows are
+// fetched, such as a stream that's read as it's needed, so the count is
+// fetched again after every fetch.
+type growingRowSource interface {
+	RowSource
+
+	rowCountGrows()
+}
+
+// defaultRowSourcePageSize is the page size used for a row source if no other
+// page size is set, since row sources are always paginated.
+const defaultRowSourcePageSize = 100
+
+// rowSourceRequest describes a fetch from the row source, so that the same
+// fetch isn't repeated and responses for old requests can be ignored.
+type rowSourceRequest struct {
+	sourceID uint32
+	queryKey string
+	offset   int
+	limit    int
+
+	fetchCount bool
+}
+
+type rowSourceLoadedMsg struct {
+	request rowSourceRequest
+
+	// count is -1 if it wasn't fetched
+	count int
+	rows  []Row
+	err   error
+}
+
+var lastRowSourceID uint32
+
+// WithRowSource sets a source that provides rows on demand instead of holding
+// every row in the table.  Any rows set with WithRows are replaced.  Setting
+// the source to nil goes back to an empty table of normal rows.
+//
+// Rows are loaded a page at a time by commands returned from Init and Update,
+// and a loading placeholder row is shown until they arrive.  If no page size
+// is set with WithPageSize, pages of 100 rows are used.  Sorting and filtering
+// are passed on to the source if it implements SortableRowSource or
+// FilterableRowSource.  Otherwise, sort indicators aren't shown and the filter
+// input can't be focused, since the rows wouldn't follow them.  InsertRows,
+// UpdateRow, DeleteRows, and UpsertRows have no effect while a row source is
+// used.
+func (m Model) WithRowSource(source RowSource) Model {
+	m.rowSource = source
+	m.rowSourceID = atomic.AddUint32(&lastRowSourceID, 1)
+	m.rowSourceCount = 0
+	m.rowSourceOffset = 0
+	m.rowSourceLoadedQueryKey = ""
+	m.rowSourceRequest = rowSourceRequest{}
+	m.rowSourceInitRequest = &rowSourceRequest{}
+	m.rowSourceSavedRows = nil
+	m.rowSourceError = nil
+
+	if source != nil && m.pageSize == 0 {
+		m.pageSize = defaultRowSourcePageSize
+	}
+
+	m.rows = nil
+	m.rowsIndexByID = nil
+	m.treeRows = false
+	m.visibleRowCacheUpdated = false
+	m.rowCursorIndex = 0
+	m.currentPage = 0
+
+	return m
+}
+
+// RefreshRowSource discards any rows that were loaded from the row source so
+// that they're fetched again, along with the total row count.  Use this when
+// the underlying data has changed.  The highlighted row index is kept.
+func (m Model) RefreshRowSource() Model {
+	if m.rowSource == nil {
+		return m
+	}
+
+	m.rowSourceID = atomic.AddUint32(&lastRowSourceID, 1)
+	m.rowSourceLoadedQueryKey = ""
+	m.rowSourceRequest = rowSourceRequest{}
+	m.rowSourceInitRequest = &rowSourceRequest{}
+
+	return m
+}
+
+// WithLoadingRow sets the row shown in place of rows that are still being
+// loaded from the row source.  By default, every cell shows an ellipsis.
+func (m Model) WithLoadingRow(row Row) Model {
+	m.loadingRow = row
+
+	return m
+}
+
+// GetIsLoadingRows returns true if rows are currently being loaded from the
+// row source.
+func (m *Model) GetIsLoadingRows() bool {
+	return m.rowSource != nil && m.rowSourceError == nil && m.rowSourceRequest.sourceID == m.rowSourceID &&
+		!m.isRowSourceRequestLoaded(m.rowSourceRequest)
+}
+
+func (m *Model) loadingPlaceholderRow() Row {
+	if m.loadingRow.Data != nil {
+		return m.loadingRow
+	}
+
+	data := RowData{}
+
+	for _, column := range m.columns {
+		if column.key != columnKeySelect {
+			data[column.key] = "…"
+		}
+	}
+
+	return NewRow(data)
+}
+
+// queriedRowSource returns the row source with the current sorting and
+// filtering applied, if the source supports them.
+func (m *Model) queriedRowSource() RowSource {
+	source := m.rowSource
+
+	if sortColumns := m.rowSourceSortColumns(); len(sortColumns) > 0 {
+		source = source.(SortableRowSource).WithSort(sortColumns)
+	}
+
+	if filterable, ok := source.(FilterableRowSource); ok && m.rowSourceFilter() != "" {
+		source = filterable.WithFilter(m.rowSourceFilter())
+	}
+
+	return source
+}
+
+// rowSourceSortColumns returns the sorting passed on to the row source with the
+// primary sort first, which is empty if the source can't sort.
+func (m *Model) rowSourceSortColumns() []SortColumn {
+	if _, ok := m.rowSource.(SortableRowSource); !ok || len(m.sortOrder) == 0 {
+		return nil
+	}
+
+	// The row source expects the primary sort first
+	sortColumns := m.GetColumnSorting()
+
+	for i, j := 0, len(sortColumns)-1; i < j; i, j = i+1, j-1 {
+		sortColumns[i], sortColumns[j] = sortColumns[j], sortColumns[i]
+	}
+
+	return sortColumns
+}
+
+// rowSourceFilter returns the filter text passed on to the row source, which
+// is empty if the source can't filter.
+func (m *Model) rowSourceFilter() string {
+	if _, ok := m.rowSource.(FilterableRowSource); !ok || !m.filtered {
+		return ""
+	}
+
+	return m.filterTextInput.Value()
+}
+
+// rowSourceQueryKey identifies the sorting and filtering passed on to the row
+// source, so that rows loaded for a different query can be recognized.  Any
+// sorting or filtering the source can't apply is left out, since it doesn't
+// change the rows.
+func (m *Model) rowSourceQueryKey() string {
+	return fmt.Sprintf("%v|%q", m.rowSourceSortColumns(), m.rowSourceFilter())
+}
+
+// isRowSourceLoaded returns true if the loaded rows are for the current query.
+func (m *Model) isRowSourceLoaded() bool {
+	return m.rowSourceLoadedQueryKey != "" && m.rowSourceLoadedQueryKey == m.rowSourceQueryKey()
+}
+
+// rowSourceIgnoresSort returns true if a row source is set that can't sort,
+// so the rows don't follow the current sorting.
+func (m *Model) rowSourceIgnoresSort() bool {
+	_, ok := m.rowSource.(SortableRowSource)
+
+	return m.rowSource != nil && !ok
+}
+
+// rowSourceIgnoresFilter returns true if a row source is set that can't filter,
+// so the rows don't follow the filter input.
+func (m *Model) rowSourceIgnoresFilter() bool {
+	_, ok := m.rowSource.(FilterableRowSource)
+
+	return m.rowSource != nil && !ok
+}
+
+// wantedRowSourceRequest returns the fetch needed to show the current page.
+func (m *Model) wantedRowSourceRequest() rowSourceRequest {
+	return rowSourceRequest{
+		sourceID:   m.rowSourceID,
+		queryKey:   m.rowSourceQueryKey(),
+		offset:     m.currentPage * m.pageSize,
+		limit:      m.pageSize,
+		fetchCount: !m.isRowSourceLoaded(),
+	}
+}
+
+// adoptInitRowSourceRequest records the request made by Init as the current
+// request if nothing else has been requested since.
+func (m *Model) adoptInitRowSourceRequest() {
+	if m.rowSourceRequest == (rowSourceRequest{}) && m.rowSourceInitRequest != nil {
+		m.rowSourceRequest = *m.rowSourceInitRequest
+	}
+}
+
+func (m *Model) isRowSourceRequestLoaded(request rowSourceRequest) bool {
+	if !m.isRowSourceLoaded() || request.queryKey != m.rowSourceLoadedQueryKey || request.sourceID != m.rowSourceID {
+		return false
+	}
+
+	end := min(m.rowSourceCount, request.offset+request.limit)
+
+	return request.offset >= m.rowSourceOffset && end <= m.rowSourceOffset+len(m.rows)
+}
+
+// loadRowSourceCmd returns a command to fetch the rows for the current page
+// from the row source, if they aren't already loaded or being loaded.
+func (m *Model) loadRowSourceCmd() tea.Cmd {
+	if m.rowSource == nil {
+		return nil
+	}
+
+	m.adoptInitRowSourceRequest()
+
+	request := m.wantedRowSourceRequest()
+
+	if request == m.rowSourceRequest || m.isRowSourceRequestLoaded(request) {
+		return nil
+	}
+
+	m.rowSourceRequest = request
+
+	source := m.queriedRowSource()
+
+	return func() tea.Msg {
+		return fetchFromRowSource(source, request)
+	}
+}
+
+func fetchFromRowSource(source RowSource, request rowSourceRequest) rowSourceLoadedMsg {
+	msg := rowSourceLoadedMsg{
+		request: request,
+		count:   -1,
+	}
+
+	if request.fetchCount {
+		msg.count, msg.err = source.RowCount()
+
+		if msg.err != nil {
+			return msg
+		}
+	}
+
+	msg.rows, msg.err = source.FetchRows(request.offset, request.limit)
+
+	if _, ok := source.(growingRowSource); ok && msg.err == nil {
+		msg.count, msg.err = source.RowCount()
+	}
+
+	return msg
+}
+
+func (m *Model) handleRowSourceLoaded(msg rowSourceLoadedMsg) {
+	request := msg.request
+
+	if request.sourceID != m.rowSourceID || request.queryKey != m.rowSourceQueryKey() {
+		return
+	}
+
+	m.adoptInitRowSourceRequest()
+
+	if m.rowSourceRequest != request {
+		return
+	}
+
+	if msg.err != nil {
+		m.rowSourceError = msg.err
+
+		return
+	}
+
+	m.rowSourceError = nil
+
+	if msg.count >= 0 {
+		m.rowSourceCount = msg.count
+	} else if !m.isRowSourceLoaded() {
+		// Shouldn't happen since the count is always fetched for a new query
+		return
+	}
+
+	rows := make([]Row, len(msg.rows))
+	copy(rows, msg.rows)
+
+	m.saveRowSourceState()
+	m.preserveRowState(rows, m.rowSourceSavedRows)
+
+	m.rows = rows
+	m.rowsIndexByID = nil
+	m.rowSourceOffset = request.offset
+	m.rowSourceLoadedQueryKey = request.queryKey
+	m.visibleRowCacheUpdated = false
+
+	m.remeasureAutoColumns()
+
+	m.clampRowCursor()
+}
+
+// saveRowSourceState remembers the selection and expansion of the loaded rows
+// by ID, so that they're kept when the rows are fetched again after moving to
+// another page.  Only rows with some state set are kept.  The saved rows are
+// replaced rather than modified since copies of the model share them.
+func (m *Model) saveRowSourceState() {
+	savedRows := make(map[string]Row, len(m.rowSourceSavedRows))
+
+	for id, row := range m.rowSourceSavedRows {
+		savedRows[id] = row
+	}
+
+	walkRowTree(m.rows, func(row Row) {
+		if row.userID == "" {
+			return
+		}
+
+		if !row.selected && !row.expanded && !row.detailExpanded {
+			delete(savedRows, row.userID)
+
+			return
+		}
+
+		// Only the state is needed, not the data
+		savedRows[row.userID] = Row{
+			id:             row.id,
+			userID:         row.userID,
+			selected:       row.selected,
+			expanded:       row.expanded,
+			detailExpanded: row.detailExpanded,
+		}
+	})
+
+	m.rowSourceSavedRows = savedRows
+}
@@ -0,0 +1,380 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRowSource struct {
+	names   []string
+	fetches *[]string
+	err     error
+}
+
+func newFakeRowSource(count int) fakeRowSource {
+	names := make([]string, count)
+
+	for i := range names {
+		names[i] = fmt.Sprintf("row%02d", i)
+	}
+
+	return fakeRowSource{
+		names:   names,
+		fetches: &[]string{},
+	}
+}
+
+func (s fakeRowSource) RowCount() (int, error) {
+	return len(s.names), s.err
+}
+
+func (s fakeRowSource) FetchRows(offset, limit int) ([]Row, error) {
+	*s.fetches = append(*s.fetches, fmt.Sprintf("%d+%d", offset, limit))
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	rows := []Row{}
+
+	for i := offset; i < offset+limit && i < len(s.names); i++ {
+		rows = append(rows, NewRow(RowData{"name": s.names[i]}).WithID(s.names[i]))
+	}
+
+	return rows, nil
+}
+
+type sortableFakeRowSource struct {
+	fakeRowSource
+}
+
+func (s sortableFakeRowSource) WithSort(sortColumns []SortColumn) RowSource {
+	names := make([]string, len(s.names))
+	copy(names, s.names)
+
+	if sortColumns[0].Direction == SortDirectionDesc {
+		sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	} else {
+		sort.Strings(names)
+	}
+
+	s.names = names
+
+	return s
+}
+
+func (s sortableFakeRowSource) WithFilter(filter string) RowSource {
+	names := []string{}
+
+	for _, name := range s.names {
+		if strings.Contains(name, filter) {
+			names = append(names, name)
+		}
+	}
+
+	s.names = names
+
+	return s
+}
+
+// runRowSourceCmd runs a load command and passes the result back to the model,
+// as Bubble Tea would.
+func runRowSourceCmd(t *testing.T, model Model, cmd tea.Cmd) Model {
+	t.Helper()
+
+	if !assert.NotNil(t, cmd, "Expected a command to load rows") {
+		return model
+	}
+
+	model, cmd = model.Update(cmd())
+
+	assert.Nil(t, cmd, "Should not need another load")
+
+	return model
+}
+
+func genRowSourceModel(source RowSource) Model {
+	return New([]Column{
+		NewColumn("name", "Name", 6).WithFiltered(true),
+	}).WithRowSource(source).WithPageSize(3).Focused(true)
+}
+
+func TestRowSourceLoadsOnlyCurrentPage(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source)
+
+	assert.Equal(t, 0, model.TotalRows())
+	assert.Len(t, *source.fetches, 0, "Should not fetch until a command is run")
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	assert.Equal(t, []string{"0+3"}, *source.fetches)
+	assert.Equal(t, 10, model.TotalRows())
+	assert.Equal(t, 4, model.MaxPages())
+	assert.Equal(t, "row00", model.HighlightedRow().ID())
+	assert.Len(t, model.GetVisibleRows(), 3, "Should only hold the loaded rows")
+	assert.False(t, model.GetIsLoadingRows())
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	assert.Equal(t, 2, model.CurrentPage())
+	assert.True(t, model.GetIsLoadingRows())
+	assert.Contains(t, model.View(), "┃     …┃", "Should show placeholders while loading")
+
+	model = runRowSourceCmd(t, model, cmd)
+
+	assert.Equal(t, []string{"0+3", "3+3"}, *source.fetches)
+	assert.Equal(t, "row03", model.HighlightedRow().ID())
+	assert.Contains(t, model.View(), "┃ row05┃")
+
+	// Moving within the loaded page shouldn't fetch anything
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, "row04", model.HighlightedRow().ID())
+}
+
+func TestRowSourceIgnoresStaleResults(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	model, firstCmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model, secondCmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	assert.NotNil(t, firstCmd)
+
+	staleMsg := firstCmd()
+
+	model = runRowSourceCmd(t, model, secondCmd)
+	model, _ = model.Update(staleMsg)
+
+	assert.Equal(t, 3, model.CurrentPage())
+	assert.Equal(t, "row06", model.HighlightedRow().ID())
+}
+
+func TestRowSourceIgnoresOtherTables(t *testing.T) {
+	model := genRowSourceModel(newFakeRowSource(10))
+	other := genRowSourceModel(newFakeRowSource(2))
+
+	other, _ = other.Update(model.Init()())
+
+	assert.Equal(t, 0, other.TotalRows())
+}
+
+func TestRowSourcePushesDownSortAndFilter(t *testing.T) {
+	source := sortableFakeRowSource{newFakeRowSource(12)}
+	model := genRowSourceModel(source).Filtered(true)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	model = model.SortByDesc("name")
+
+	assert.Contains(t, model.View(), "┃     …┃", "Should not show rows loaded for the old sort")
+
+	model, cmd := model.Update(nil)
+	model = runRowSourceCmd(t, model, cmd)
+
+	assert.Equal(t, "row11", model.HighlightedRow().ID())
+
+	model = model.WithFilterInputValue("row1")
+	model, cmd = model.Update(nil)
+	model = runRowSourceCmd(t, model, cmd)
+
+	assert.Equal(t, 2, model.TotalRows())
+	assert.Equal(t, "row11", model.HighlightedRow().ID())
+	assert.Equal(t, []string{"0+3", "0+3", "0+3"}, *source.fetches)
+}
+
+func TestRowSourceIgnoresSortAndFilterItCantApply(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source).
+		Filtered(true).
+		WithSortIndicators("▲", "▼").
+		WithInteractiveSorting(true)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	assert.Nil(t, cmd, "Should not fetch again for sorting the source can't apply")
+	assert.NotEmpty(t, model.GetColumnSorting())
+	assert.NotContains(t, model.View(), "▲")
+	assert.Equal(t, "row00", model.HighlightedRow().ID())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+
+	assert.False(t, model.GetIsFilterInputFocused())
+	assert.Equal(t, []string{"0+3"}, *source.fetches)
+}
+
+func TestRowSourceKeepsSelectionOnPage(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source).SelectableRows(true)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Len(t, model.SelectedRows(), 1)
+
+	model = model.RefreshRowSource()
+	model, cmd := model.Update(nil)
+	model = runRowSourceCmd(t, model, cmd)
+
+	assert.Len(t, model.SelectedRows(), 1)
+	assert.Equal(t, []string{"0+3", "0+3"}, *source.fetches)
+}
+
+func TestRowSourceShowsErrorsWithoutRetrying(t *testing.T) {
+	source := newFakeRowSource(10)
+	source.err = errors.New("database is gone")
+
+	model := genRowSourceModel(source)
+
+	model, _ = model.Update(model.Init()())
+
+	assert.Contains(t, model.View(), "┃datab…┃")
+
+	_, cmd := model.Update(nil)
+
+	assert.Nil(t, cmd)
+}
+
+func TestRowSourceCustomLoadingRow(t *testing.T) {
+	model := genRowSourceModel(newFakeRowSource(10)).
+		WithLoadingRow(NewRow(RowData{"name": "wait"}))
+
+	model = runRowSourceCmd(t, model, model.Init())
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	assert.Contains(t, model.View(), "┃  wait┃")
+}
+
+func TestRowSourceWithoutPaginationUsesDefaultPageSize(t *testing.T) {
+	source := newFakeRowSource(150)
+	model := New([]Column{NewColumn("name", "Name", 6)}).WithRowSource(source)
+
+	assert.Equal(t, defaultRowSourcePageSize, model.PageSize())
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	assert.Equal(t, []string{"0+100"}, *source.fetches)
+	assert.Equal(t, 150, model.TotalRows())
+	assert.Equal(t, 2, model.MaxPages())
+
+	model = model.WithNoPagination()
+
+	assert.Equal(t, defaultRowSourcePageSize, model.PageSize(), "Should stay paginated")
+}
+
+func TestRowSourceInitRequestNotRepeated(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source)
+
+	initCmd := model.Init()
+
+	model, cmd := model.Update(nil)
+
+	assert.Nil(t, cmd, "Should not fetch the first page again")
+
+	model = runRowSourceCmd(t, model, initCmd)
+
+	assert.Equal(t, []string{"0+3"}, *source.fetches)
+	assert.Equal(t, "row00", model.HighlightedRow().ID())
+}
+
+func TestRowSourceKeepsSelectionAcrossPages(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source).SelectableRows(true)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model = runRowSourceCmd(t, model, cmd)
+
+	assert.Empty(t, model.SelectedRows())
+
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model = runRowSourceCmd(t, model, cmd)
+
+	selected := model.SelectedRows()
+
+	if len(selected) != 1 {
+		t.Fatalf("Expected one selected row, got %v", selected)
+	}
+
+	assert.Equal(t, "row01", selected[0].ID())
+	assert.Equal(t, []string{"0+3", "3+3", "0+3"}, *source.fetches)
+}
+
+func TestRowSourceSelectedRowIDsAcrossPages(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source).SelectableRows(true)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	// Select row00 and row02 on the first page
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model = runRowSourceCmd(t, model, cmd)
+
+	// Select row04 on the second page
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Len(t, model.SelectedRows(), 1, "Only the loaded page's rows should be returned")
+	assert.Equal(t, []string{"row04", "row00", "row02"}, model.SelectedRowIDs())
+
+	// Deselect row04 and go back to the first page to deselect row00
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model = runRowSourceCmd(t, model, cmd)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	assert.Equal(t, []string{"row02"}, model.SelectedRowIDs())
+}
+
+func TestRowSourceSavedSelectionNotShared(t *testing.T) {
+	source := newFakeRowSource(10)
+	model := genRowSourceModel(source).SelectableRows(true)
+
+	model = runRowSourceCmd(t, model, model.Init())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	model = runRowSourceCmd(t, model, cmd)
+
+	earlier := model
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model = runRowSourceCmd(t, model, cmd)
+
+	assert.Contains(t, model.rowSourceSavedRows, "row03")
+	assert.NotContains(t, earlier.rowSourceSavedRows, "row03", "Earlier copies should not see later selections")
+}
+
+func TestRowSourceReplacedByRows(t *testing.T) {
+	model := genRowSourceModel(newFakeRowSource(4))
+
+	model = runRowSourceCmd(t, model, model.Init())
+	model = model.WithRows([]Row{NewRow(RowData{"name": "static"})})
+
+	assert.Equal(t, 1, model.TotalRows())
+	assert.Nil(t, model.Init())
+}
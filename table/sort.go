@@ -34,7 +34,7 @@ func (m Model) SortByAsc(columnKey string) Model {
 		},
 	}
 
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m
 }
@@ -51,7 +51,7 @@ func (m Model) SortByDesc(columnKey string) Model {
 		},
 	}
 
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m
 }
@@ -66,7 +66,7 @@ func (m Model) ThenSortByAsc(columnKey string) Model {
 		},
 	}, m.sortOrder...)
 
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m
 }
@@ -81,14 +81,128 @@ func (m Model) ThenSortByDesc(columnKey string) Model {
 		},
 	}, m.sortOrder...)
 
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m
 }
 
+// sortDirectionFor returns the current sort direction of the given column and
+// its index in the sort order, or -1 if the column isn't sorted.
+func (m *Model) sortDirectionFor(columnKey string) (SortDirection, int) {
+	for i, sortColumn := range m.sortOrder {
+		if sortColumn.ColumnKey == columnKey {
+			return sortColumn.Direction, i
+		}
+	}
+
+	return SortDirectionAsc, -1
+}
+
+// cycleSort moves the given column to its next sort state, going from
+// unsorted to ascending to descending and back to unsorted.  If keepOthers is
+// true, any other sorted columns are kept and a newly sorted column is added
+// with the lowest precedence.  Otherwise the column replaces all other sorting.
+func (m *Model) cycleSort(columnKey string, keepOthers bool) {
+	direction, index := m.sortDirectionFor(columnKey)
+
+	var sortOrder []SortColumn
+
+	if keepOthers {
+		sortOrder = make([]SortColumn, len(m.sortOrder))
+		copy(sortOrder, m.sortOrder)
+	}
+
+	switch {
+	case index == -1:
+		sortOrder = append([]SortColumn{
+			{
+				ColumnKey: columnKey,
+				Direction: SortDirectionAsc,
+			},
+		}, sortOrder...)
+
+	case direction == SortDirectionAsc && keepOthers:
+		sortOrder[index].Direction = SortDirectionDesc
+
+	case direction == SortDirectionAsc:
+		sortOrder = []SortColumn{
+			{
+				ColumnKey: columnKey,
+				Direction: SortDirectionDesc,
+			},
+		}
+
+	case keepOthers:
+		sortOrder = append(sortOrder[:index], sortOrder[index+1:]...)
+	}
+
+	m.sortOrder = sortOrder
+	m.invalidateQuery()
+
+	m.appendUserEvent(UserEventSortChanged{
+		SortColumns: m.GetColumnSorting(),
+	})
+}
+
+// sortIndicator returns the indicator to show next to the given column's
+// header title, if any.
+func (m Model) sortIndicator(columnKey string) string {
+	direction, index := m.sortDirectionFor(columnKey)
+
+	if index == -1 || m.rowSourceIgnoresSort() {
+		return ""
+	}
+
+	indicator := m.sortIndicatorAsc
+
+	if direction == SortDirectionDesc {
+		indicator = m.sortIndicatorDesc
+	}
+
+	if indicator == "" || len(m.sortOrder) == 1 {
+		return indicator
+	}
+
+	// The last sort column has the highest precedence
+	return fmt.Sprintf("%s%d", indicator, len(m.sortOrder)-index)
+}
+
+// columnSort is how the values of a column are compared when sorting, as set
+// by Column.WithSortFunc, Column.WithSortValid, and Column.WithMissingSort.
+type columnSort struct {
+	sortFunc    SortFunc
+	sortValid   SortValidFunc
+	missingSort MissingSort
+}
+
+// columnSorts returns how each column with custom sorting is sorted, keyed by
+// column key, or nil if all columns use the default sorting.
+func (m Model) columnSorts() map[string]columnSort {
+	var sorts map[string]columnSort
+
+	for _, column := range m.columns {
+		if column.sortFunc == nil && column.sortValid == nil && column.missingSort == MissingSortDefault {
+			continue
+		}
+
+		if sorts == nil {
+			sorts = make(map[string]columnSort)
+		}
+
+		sorts[column.key] = columnSort{
+			sortFunc:    column.sortFunc,
+			sortValid:   column.sortValid,
+			missingSort: column.missingSort,
+		}
+	}
+
+	return sorts
+}
+
 type sortableTable struct {
 	rows     []Row
 	byColumn SortColumn
+	sorts    map[string]columnSort
 }
 
 func (s *sortableTable) Len() int {
@@ -101,58 +215,131 @@ func (s *sortableTable) Swap(i, j int) {
 	s.rows[j] = old
 }
 
-func (s *sortableTable) extractString(i int, column string) string {
-	iData, exists := s.rows[i].Data[column]
+func extractSortString(row Row, column string) string {
+	data, exists := row.Data[column]
 
 	if !exists {
 		return ""
 	}
 
-	switch iData := iData.(type) {
+	switch data := data.(type) {
 	case StyledCell:
-		return fmt.Sprintf("%v", iData.Data)
+		return fmt.Sprintf("%v", data.Data)
 
 	case string:
-		return iData
+		return data
 
 	default:
-		return fmt.Sprintf("%v", iData)
+		return fmt.Sprintf("%v", data)
 	}
 }
 
-func (s *sortableTable) extractNumber(i int, column string) (float64, bool) {
-	iData, exists := s.rows[i].Data[column]
+func extractSortNumber(row Row, column string) (float64, bool) {
+	data, exists := row.Data[column]
 
 	if !exists {
 		return 0, false
 	}
 
-	return asNumber(iData)
+	return asNumber(data)
 }
 
-func (s *sortableTable) Less(first, second int) bool {
-	firstNum, firstNumIsValid := s.extractNumber(first, s.byColumn.ColumnKey)
-	secondNum, secondNumIsValid := s.extractNumber(second, s.byColumn.ColumnKey)
+// extractSortValue returns the raw value to pass to a SortFunc, which is nil
+// if the row is missing the column.
+func extractSortValue(row Row, column string) any {
+	data := row.Data[column]
+
+	if styled, isStyled := data.(StyledCell); isStyled {
+		return styled.Data
+	}
+
+	return data
+}
+
+// lessRows returns true if the first row should be sorted before the second
+// row when sorting by the given column.
+func lessRows(byColumn SortColumn, sorts map[string]columnSort, first, second Row) bool {
+	sorting := sorts[byColumn.ColumnKey]
+
+	if sorting.missingSort != MissingSortDefault {
+		firstMissing := extractSortValue(first, byColumn.ColumnKey) == nil
+		secondMissing := extractSortValue(second, byColumn.ColumnKey) == nil
+
+		if firstMissing || secondMissing {
+			// Missing values go to the same place regardless of direction
+			return firstMissing && !secondMissing && sorting.missingSort == MissingSortFirst ||
+				secondMissing && !firstMissing && sorting.missingSort == MissingSortLast
+		}
+	}
+
+	if sorting.sortValid != nil {
+		firstValid := sorting.sortValid(extractSortValue(first, byColumn.ColumnKey))
+		secondValid := sorting.sortValid(extractSortValue(second, byColumn.ColumnKey))
+
+		// Invalid values go last regardless of direction
+		if firstValid != secondValid {
+			return firstValid
+		}
+	}
+
+	if sorting.sortFunc != nil {
+		cmp := sorting.sortFunc(
+			extractSortValue(first, byColumn.ColumnKey),
+			extractSortValue(second, byColumn.ColumnKey),
+		)
+
+		if byColumn.Direction == SortDirectionAsc {
+			return cmp < 0
+		}
+
+		return cmp > 0
+	}
+
+	firstNum, firstNumIsValid := extractSortNumber(first, byColumn.ColumnKey)
+	secondNum, secondNumIsValid := extractSortNumber(second, byColumn.ColumnKey)
 
 	if firstNumIsValid && secondNumIsValid {
-		if s.byColumn.Direction == SortDirectionAsc {
+		if byColumn.Direction == SortDirectionAsc {
 			return firstNum < secondNum
 		}
 
 		return firstNum > secondNum
 	}
 
-	firstVal := s.extractString(first, s.byColumn.ColumnKey)
-	secondVal := s.extractString(second, s.byColumn.ColumnKey)
+	firstVal := extractSortString(first, byColumn.ColumnKey)
+	secondVal := extractSortString(second, byColumn.ColumnKey)
 
-	if s.byColumn.Direction == SortDirectionAsc {
+	if byColumn.Direction == SortDirectionAsc {
 		return firstVal < secondVal
 	}
 
 	return firstVal > secondVal
 }
 
-func getSortedRows(sortOrder []SortColumn, rows []Row) []Row {
+// compareRows compares two rows using the full sort order, returning a
+// negative number if the first row comes first, a positive number if the
+// second row comes first, or 0 if they're equal.  This gives the same order as
+// getSortedRows, aside from the stable ordering of equal rows.
+func compareRows(sortOrder []SortColumn, sorts map[string]columnSort, first, second Row) int {
+	// The last sort column is applied last, so it has the highest precedence
+	for i := len(sortOrder) - 1; i >= 0; i-- {
+		if lessRows(sortOrder[i], sorts, first, second) {
+			return -1
+		}
+
+		if lessRows(sortOrder[i], sorts, second, first) {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (s *sortableTable) Less(first, second int) bool {
+	return lessRows(s.byColumn, s.sorts, s.rows[first], s.rows[second])
+}
+
+func getSortedRows(sortOrder []SortColumn, sorts map[string]columnSort, rows []Row) []Row {
 	var sortedRows []Row
 	if len(sortOrder) == 0 {
 		sortedRows = rows
@@ -167,6 +354,7 @@ func getSortedRows(sortOrder []SortColumn, rows []Row) []Row {
 		sorted := &sortableTable{
 			rows:     sortedRows,
 			byColumn: byColumn,
+			sorts:    sorts,
 		}
 
 		sort.Stable(sorted)
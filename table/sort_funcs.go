@@ -0,0 +1,377 @@
+package table
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SortFunc compares two raw values of a column when sorting, returning a
+// negative number if the first value comes first in ascending order, a positive
+// number if the second value comes first, or 0 if they're equal.  StyledCell
+// values are unwrapped, and rows missing the column pass a nil value unless
+// placed by Column.WithMissingSort.  The result is reversed when sorting in
+// descending order.
+type SortFunc func(first, second any) int
+
+// SortValidFunc returns true if a raw value of a column can be sorted by the
+// column's SortFunc, such as IsSemver for SortSemver.  Values that aren't valid
+// are placed last in both sort directions by Column.WithSortValid.
+type SortValidFunc func(value any) bool
+
+// MissingSort is where rows that are missing data are placed when sorting.
+type MissingSort int
+
+const (
+	// MissingSortDefault sorts missing data the same as an empty string, or
+	// passes it to the column's SortFunc as nil.
+	MissingSortDefault MissingSort = iota
+
+	// MissingSortFirst always places rows with missing data first.
+	MissingSortFirst
+
+	// MissingSortLast always places rows with missing data last.
+	MissingSortLast
+)
+
+// SortNatural is a SortFunc that compares values as strings, where any runs of
+// digits are compared by their numeric value, so that "file2" comes before
+// "file10" and "v1.9" comes before "v1.10".
+func SortNatural(first, second any) int {
+	return compareNatural(sortString(first), sortString(second))
+}
+
+// SortSemver is a SortFunc that compares semantic versions such as "v1.2.3" or
+// "2.0.0-rc.1", with pre-release versions before their release.  The "v"
+// prefix and build metadata are ignored, and missing minor or patch numbers
+// count as 0.  Values that aren't versions come after versions in ascending
+// order and are compared with SortNatural.  Use IsSemver with
+// Column.WithSortValid to keep them last in both directions.
+func SortSemver(first, second any) int {
+	firstVersion, firstValid := parseSemver(sortString(first))
+	secondVersion, secondValid := parseSemver(sortString(second))
+
+	if cmp, ok := compareValidity(firstValid, secondValid); ok {
+		if cmp == 0 {
+			return SortNatural(first, second)
+		}
+
+		return cmp
+	}
+
+	return firstVersion.compare(secondVersion)
+}
+
+// SortIP is a SortFunc that compares IP addresses given as strings, net.IP, or
+// netip.Addr values by their numeric value, with IPv4 addresses before IPv6
+// addresses.  Values that aren't IP addresses come after addresses in
+// ascending order and are compared with SortNatural.  Use IsIP with
+// Column.WithSortValid to keep them last in both directions.
+func SortIP(first, second any) int {
+	firstAddr, firstValid := asIP(first)
+	secondAddr, secondValid := asIP(second)
+
+	if cmp, ok := compareValidity(firstValid, secondValid); ok {
+		if cmp == 0 {
+			return SortNatural(first, second)
+		}
+
+		return cmp
+	}
+
+	return firstAddr.Compare(secondAddr)
+}
+
+// SortTime is a SortFunc that compares times given as time.Time values,
+// RFC 3339 strings, or integer Unix timestamps in seconds.  Values that aren't
+// times come after times in ascending order and are compared with SortNatural.
+// Use IsTime with Column.WithSortValid to keep them last in both directions.
+func SortTime(first, second any) int {
+	firstTime, firstValid := asSortTime(first)
+	secondTime, secondValid := asSortTime(second)
+
+	if cmp, ok := compareValidity(firstValid, secondValid); ok {
+		if cmp == 0 {
+			return SortNatural(first, second)
+		}
+
+		return cmp
+	}
+
+	switch {
+	case firstTime.Before(secondTime):
+		return -1
+
+	case firstTime.After(secondTime):
+		return 1
+	}
+
+	return 0
+}
+
+// IsSemver is a SortValidFunc for SortSemver that returns true if the value is
+// a semantic version.
+func IsSemver(value any) bool {
+	_, valid := parseSemver(sortString(value))
+
+	return valid
+}
+
+// IsIP is a SortValidFunc for SortIP that returns true if the value is an IP
+// address.
+func IsIP(value any) bool {
+	_, valid := asIP(value)
+
+	return valid
+}
+
+// IsTime is a SortValidFunc for SortTime that returns true if the value is a
+// time.
+func IsTime(value any) bool {
+	_, valid := asSortTime(value)
+
+	return valid
+}
+
+// compareValidity orders valid values before invalid ones.  Returns false if
+// both values are valid and need to be compared, or 0 if both are invalid.
+func compareValidity(firstValid, secondValid bool) (int, bool) {
+	switch {
+	case firstValid && secondValid:
+		return 0, false
+
+	case firstValid:
+		return -1, true
+
+	case secondValid:
+		return 1, true
+	}
+
+	return 0, true
+}
+
+// sortString returns the value as a string to sort by, where nil is empty.
+func sortString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+
+	case string:
+		return value
+	}
+
+	return fmt.Sprint(value)
+}
+
+// compareNatural compares strings with runs of digits compared by value.
+//
+//nolint:cyclop
+func compareNatural(first, second string) int {
+	firstRunes := []rune(first)
+	secondRunes := []rune(second)
+	i, j := 0, 0
+
+	for i < len(firstRunes) && j < len(secondRunes) {
+		if unicode.IsDigit(firstRunes[i]) && unicode.IsDigit(secondRunes[j]) {
+			firstEnd := digitRunEnd(firstRunes, i)
+			secondEnd := digitRunEnd(secondRunes, j)
+
+			if cmp := compareDigits(string(firstRunes[i:firstEnd]), string(secondRunes[j:secondEnd])); cmp != 0 {
+				return cmp
+			}
+
+			i, j = firstEnd, secondEnd
+
+			continue
+		}
+
+		if firstRunes[i] != secondRunes[j] {
+			if firstRunes[i] < secondRunes[j] {
+				return -1
+			}
+
+			return 1
+		}
+
+		i++
+		j++
+	}
+
+	return compareInts(len(firstRunes)-i, len(secondRunes)-j)
+}
+
+// digitRunEnd returns the index just past the run of digits starting at start.
+func digitRunEnd(runes []rune, start int) int {
+	end := start
+
+	for end < len(runes) && unicode.IsDigit(runes[end]) {
+		end++
+	}
+
+	return end
+}
+
+// compareDigits compares two strings of digits by value, without limits on
+// their size.  Equal values with more leading zeros come last.
+func compareDigits(first, second string) int {
+	firstTrimmed := strings.TrimLeft(first, "0")
+	secondTrimmed := strings.TrimLeft(second, "0")
+
+	if cmp := compareInts(len(firstTrimmed), len(secondTrimmed)); cmp != 0 {
+		return cmp
+	}
+
+	if cmp := strings.Compare(firstTrimmed, secondTrimmed); cmp != 0 {
+		return cmp
+	}
+
+	return compareInts(len(first), len(second))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// semver is a parsed semantic version.
+type semver struct {
+	core       [3]int
+	prerelease []string
+}
+
+// parseSemver parses a semantic version, allowing a "v" prefix and missing
+// minor or patch numbers.
+func parseSemver(str string) (semver, bool) {
+	const maxCoreParts = 3
+
+	str = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(str), "v"), "V")
+	str, _, _ = strings.Cut(str, "+")
+	core, prerelease, hasPrerelease := strings.Cut(str, "-")
+
+	parts := strings.Split(core, ".")
+
+	if len(parts) > maxCoreParts {
+		return semver{}, false
+	}
+
+	version := semver{}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+
+		if err != nil || number < 0 {
+			return semver{}, false
+		}
+
+		version.core[i] = number
+	}
+
+	if hasPrerelease {
+		if prerelease == "" {
+			return semver{}, false
+		}
+
+		version.prerelease = strings.Split(prerelease, ".")
+	}
+
+	return version, true
+}
+
+func (v semver) compare(other semver) int {
+	for i := range v.core {
+		if cmp := compareInts(v.core[i], other.core[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	// A release comes after any of its pre-releases
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+
+	case len(v.prerelease) == 0:
+		return 1
+
+	case len(other.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if cmp := comparePrereleaseIdentifiers(v.prerelease[i], other.prerelease[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareInts(len(v.prerelease), len(other.prerelease))
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers by value, before
+// any other identifiers which are compared as strings.
+func comparePrereleaseIdentifiers(first, second string) int {
+	firstNumber, firstErr := strconv.Atoi(first)
+	secondNumber, secondErr := strconv.Atoi(second)
+
+	switch {
+	case firstErr == nil && secondErr == nil:
+		return compareInts(firstNumber, secondNumber)
+
+	case firstErr == nil:
+		return -1
+
+	case secondErr == nil:
+		return 1
+	}
+
+	return strings.Compare(first, second)
+}
+
+// asIP returns the value as an IP address, treating IPv4 addresses mapped to
+// IPv6 as IPv4.
+func asIP(value any) (netip.Addr, bool) {
+	var (
+		addr netip.Addr
+		ok   bool
+	)
+
+	switch value := value.(type) {
+	case netip.Addr:
+		addr, ok = value, value.IsValid()
+
+	case net.IP:
+		addr, ok = netip.AddrFromSlice(value)
+
+	case string:
+		var err error
+
+		addr, err = netip.ParseAddr(strings.TrimSpace(value))
+		ok = err == nil
+	}
+
+	return addr.Unmap(), ok
+}
+
+// asSortTime returns the value as a time, including RFC 3339 strings.
+func asSortTime(value any) (time.Time, bool) {
+	if str, isString := value.(string); isString {
+		parsed, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(str))
+
+		return parsed, err == nil
+	}
+
+	if value == nil {
+		return time.Time{}, false
+	}
+
+	return asTime(value)
+}
@@ -0,0 +1,174 @@
+package table
+
+import (
+	"math"
+	"net"
+	"net/netip"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sortedWith(sortFunc SortFunc, values ...any) []any {
+	sorted := make([]any, len(values))
+	copy(sorted, values)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sortFunc(sorted[i], sorted[j]) < 0
+	})
+
+	return sorted
+}
+
+func TestSortNatural(t *testing.T) {
+	assert.Equal(t,
+		[]any{nil, "file1", "file2", "file10", "file10a", "file010", "fileb"},
+		sortedWith(SortNatural, "file10a", "file10", "file2", "fileb", "file010", "file1", nil),
+	)
+	assert.Equal(t, []any{"v1.9", "v1.10", "v1.10.1"}, sortedWith(SortNatural, "v1.10.1", "v1.10", "v1.9"))
+	assert.Equal(t, 0, SortNatural("abc", "abc"))
+	assert.Equal(t, -1, SortNatural("99999999999999999999998", "99999999999999999999999"))
+	assert.Equal(t, -1, SortNatural(2, 10))
+}
+
+func TestSortSemver(t *testing.T) {
+	assert.Equal(t,
+		[]any{"0.9", "v1.0.0-alpha", "v1.0.0-alpha.1", "v1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "v1.0.0+build", "v1.9.0", "v1.10.0", "latest"},
+		sortedWith(SortSemver, "latest", "v1.10.0", "1.0.0-beta.11", "v1.0.0-alpha.beta", "v1.9.0", "1.0.0-rc.1", "v1.0.0-alpha", "1.0.0-beta.2", "0.9", "v1.0.0+build", "v1.0.0-alpha.1"),
+	)
+	assert.Equal(t, 0, SortSemver("v1.2", "1.2.0"))
+}
+
+func TestSortIP(t *testing.T) {
+	assert.Equal(t,
+		[]any{"9.9.9.9", net.ParseIP("10.0.0.2"), "10.0.0.10", netip.MustParseAddr("192.168.0.1"), "::1", "fe80::1", "localhost"},
+		sortedWith(SortIP, "fe80::1", "localhost", "10.0.0.10", "::1", netip.MustParseAddr("192.168.0.1"), net.ParseIP("10.0.0.2"), "9.9.9.9"),
+	)
+	assert.Equal(t, 0, SortIP("::ffff:10.0.0.1", "10.0.0.1"))
+}
+
+func TestSortTime(t *testing.T) {
+	base := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t,
+		[]any{base.Add(-time.Hour), "2022-01-02T03:04:05Z", base.Unix() + 1, base.Add(time.Hour), nil, "never"},
+		sortedWith(SortTime, "never", base.Add(time.Hour), nil, "2022-01-02T03:04:05Z", base.Unix()+1, base.Add(-time.Hour)),
+	)
+}
+
+func TestSortByColumnSortFunc(t *testing.T) {
+	model := New([]Column{
+		NewColumn("version", "Version", 10).WithSortFunc(SortSemver),
+	}).WithRows([]Row{
+		NewRow(RowData{"version": "v1.10.0"}),
+		NewRow(RowData{"version": NewStyledCell("v1.9.0", defaultHighlightStyle)}),
+		NewRow(RowData{"version": "v1.2.0"}),
+	})
+
+	assert.Equal(t, []string{"v1.2.0", "v1.9.0", "v1.10.0"}, sortedVersions(model.SortByAsc("version")))
+	assert.Equal(t, []string{"v1.10.0", "v1.9.0", "v1.2.0"}, sortedVersions(model.SortByDesc("version")))
+}
+
+func TestSortValidValuesFirstInBothDirections(t *testing.T) {
+	rows := []Row{
+		NewRow(RowData{"version": "unknown"}),
+		NewRow(RowData{"version": "v1.2.0"}),
+		NewRow(RowData{"version": "dev"}),
+		NewRow(RowData{"version": "v1.10.0"}),
+	}
+
+	model := New([]Column{
+		NewColumn("version", "Version", 10).WithSortFunc(SortSemver).WithSortValid(IsSemver),
+	}).WithRows(rows)
+
+	assert.Equal(t, []string{"v1.2.0", "v1.10.0", "dev", "unknown"}, sortedVersions(model.SortByAsc("version")))
+	assert.Equal(t, []string{"v1.10.0", "v1.2.0", "unknown", "dev"}, sortedVersions(model.SortByDesc("version")))
+
+	// Without a validity check, the sort function's order is simply reversed
+	model = model.WithColumns([]Column{
+		NewColumn("version", "Version", 10).WithSortFunc(SortSemver),
+	})
+
+	assert.Equal(t, []string{"unknown", "dev", "v1.10.0", "v1.2.0"}, sortedVersions(model.SortByDesc("version")))
+}
+
+func TestSortValidFuncs(t *testing.T) {
+	assert.True(t, IsSemver("v1.2.3"))
+	assert.False(t, IsSemver("latest"))
+	assert.True(t, IsIP(net.ParseIP("10.0.0.1")))
+	assert.False(t, IsIP("localhost"))
+	assert.True(t, IsTime("2022-01-02T03:04:05Z"))
+	assert.False(t, IsTime(nil))
+}
+
+func TestSortFuncPlainComparisonReversedInDescending(t *testing.T) {
+	// A subtraction based comparison can return any value, which should
+	// never be treated specially
+	subtract := func(first, second any) int {
+		return first.(int) - second.(int)
+	}
+
+	model := New([]Column{
+		NewColumn("version", "Version", 10).WithSortFunc(subtract),
+	}).WithRows([]Row{
+		NewRow(RowData{"version": 0}),
+		NewRow(RowData{"version": math.MaxInt32}),
+		NewRow(RowData{"version": 5}),
+	})
+
+	assert.Equal(t, []any{math.MaxInt32, 5, 0}, columnValuesWithMissing(model.SortByDesc("version")))
+}
+
+func sortedVersions(model Model) []string {
+	versions := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		switch version := row.Data["version"].(type) {
+		case StyledCell:
+			versions = append(versions, version.Data.(string))
+
+		case string:
+			versions = append(versions, version)
+
+		default:
+			versions = append(versions, "-")
+		}
+	}
+
+	return versions
+}
+
+func TestSortMissingFirstAndLast(t *testing.T) {
+	rows := []Row{
+		NewRow(RowData{"version": 2}),
+		NewRow(RowData{}),
+		NewRow(RowData{"version": nil}),
+		NewRow(RowData{"version": 1}),
+	}
+
+	model := New([]Column{
+		NewColumn("version", "Version", 10).WithMissingSort(MissingSortLast),
+	}).WithRows(rows)
+
+	assert.Equal(t, []any{1, 2, nil, nil}, columnValuesWithMissing(model.SortByAsc("version")))
+	assert.Equal(t, []any{2, 1, nil, nil}, columnValuesWithMissing(model.SortByDesc("version")))
+
+	model = model.WithColumns([]Column{
+		NewColumn("version", "Version", 10).WithMissingSort(MissingSortFirst).WithSortFunc(SortNatural),
+	})
+
+	assert.Equal(t, []any{nil, nil, 1, 2}, columnValuesWithMissing(model.SortByAsc("version")))
+	assert.Equal(t, []any{nil, nil, 2, 1}, columnValuesWithMissing(model.SortByDesc("version")))
+}
+
+func columnValuesWithMissing(model Model) []any {
+	values := []any{}
+
+	for _, row := range model.GetVisibleRows() {
+		values = append(values, row.Data["version"])
+	}
+
+	return values
+}
@@ -3,6 +3,7 @@ package table
 import (
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 )
@@ -157,7 +158,7 @@ func TestGetSortedRows(t *testing.T) {
 			Direction: SortDirectionAsc,
 		},
 	}
-	rows := getSortedRows(sortColumns, []Row{
+	rows := getSortedRows(sortColumns, nil, []Row{
 		NewRow(RowData{
 			"ca": "2",
 			"cb": "t-1",
@@ -186,3 +187,160 @@ func TestGetSortedRows(t *testing.T) {
 	assert.Equal(t, "t-3", rows[2].Data["cb"])
 	assert.Equal(t, "t-2", rows[3].Data["cb"])
 }
+
+func genInteractiveSortModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 6),
+		NewColumn("score", "Score", 8),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "b", "score": 2}),
+		NewRow(RowData{"name": "a", "score": 2}),
+		NewRow(RowData{"name": "c", "score": 1}),
+	}).Focused(true).WithInteractiveSorting(true)
+}
+
+func TestInteractiveSortingDisabledByDefault(t *testing.T) {
+	model := genInteractiveSortModel().WithInteractiveSorting(false)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	assert.Len(t, model.GetColumnSorting(), 0)
+	assert.Len(t, model.GetLastUpdateUserEvents(), 0)
+}
+
+func TestInteractiveSortingCyclesSingleColumn(t *testing.T) {
+	model := genInteractiveSortModel()
+
+	hitSort := func() {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	}
+
+	names := func() []string {
+		result := []string{}
+
+		for _, row := range model.GetVisibleRows() {
+			result = append(result, row.Data["name"].(string))
+		}
+
+		return result
+	}
+
+	hitSort()
+	assert.Equal(t, []string{"a", "b", "c"}, names())
+	assert.Equal(t, []UserEvent{
+		UserEventSortChanged{SortColumns: []SortColumn{{ColumnKey: "name", Direction: SortDirectionAsc}}},
+	}, model.GetLastUpdateUserEvents())
+
+	hitSort()
+	assert.Equal(t, []string{"c", "b", "a"}, names())
+
+	hitSort()
+	assert.Equal(t, []string{"b", "a", "c"}, names())
+	assert.Equal(t, []UserEvent{
+		UserEventSortChanged{SortColumns: []SortColumn{}},
+	}, model.GetLastUpdateUserEvents())
+}
+
+func TestInteractiveSortingMovesColumnCursor(t *testing.T) {
+	model := genInteractiveSortModel()
+
+	hitNext := func() {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}})
+	}
+
+	hitPrev := func() {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{','}})
+	}
+
+	hitPrev()
+	assert.Equal(t, 0, model.columnCursorIndex, "Should not move past the first column")
+
+	hitNext()
+	hitNext()
+	assert.Equal(t, 1, model.columnCursorIndex, "Should not move past the last column")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	assert.Equal(t, []SortColumn{{ColumnKey: "score", Direction: SortDirectionAsc}}, model.GetColumnSorting())
+
+	// Select column can't be chosen and shouldn't change what's chosen
+	model = model.SelectableRows(true)
+	assert.Equal(t, 2, model.columnCursorIndex)
+
+	hitPrev()
+	hitPrev()
+	assert.Equal(t, 1, model.columnCursorIndex)
+
+	model = model.SelectableRows(false)
+	assert.Equal(t, 0, model.columnCursorIndex)
+}
+
+func TestInteractiveSortingAddKeepsOtherColumns(t *testing.T) {
+	model := genInteractiveSortModel()
+
+	hitSortAdd := func() {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	}
+
+	hitSortAdd()
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}})
+	hitSortAdd()
+
+	// Score was added last, so it has lower precedence
+	assert.Equal(t, []SortColumn{
+		{ColumnKey: "score", Direction: SortDirectionAsc},
+		{ColumnKey: "name", Direction: SortDirectionAsc},
+	}, model.GetColumnSorting())
+
+	hitSortAdd()
+
+	assert.Equal(t, []SortColumn{
+		{ColumnKey: "score", Direction: SortDirectionDesc},
+		{ColumnKey: "name", Direction: SortDirectionAsc},
+	}, model.GetColumnSorting())
+
+	hitSortAdd()
+
+	assert.Equal(t, []SortColumn{
+		{ColumnKey: "name", Direction: SortDirectionAsc},
+	}, model.GetColumnSorting())
+}
+
+func TestSortIndicatorsInHeader(t *testing.T) {
+	model := genInteractiveSortModel().WithSortIndicators("▲", "▼").SortByAsc("name")
+
+	const expectedSingle = `┏━━━━━━┳━━━━━━━━┓
+┃ Name▲┃   Score┃
+┣━━━━━━╋━━━━━━━━┫
+┃     a┃       2┃
+┃     b┃       2┃
+┃     c┃       1┃
+┗━━━━━━┻━━━━━━━━┛`
+
+	assert.Equal(t, expectedSingle, model.View())
+
+	model = model.ThenSortByDesc("score")
+
+	const expectedMulti = `┏━━━━━━┳━━━━━━━━┓
+┃Name▲1┃ Score▼2┃
+┣━━━━━━╋━━━━━━━━┫
+┃     a┃       2┃
+┃     b┃       2┃
+┃     c┃       1┃
+┗━━━━━━┻━━━━━━━━┛`
+
+	assert.Equal(t, expectedMulti, model.View())
+}
+
+func TestSortIndicatorsKeepIndicatorVisibleWhenTruncated(t *testing.T) {
+	model := New([]Column{
+		NewColumn("name", "Long name", 5),
+		NewColumn("tiny", "Tiny", 1),
+	}).WithSortIndicators("▲", "▼").SortByDesc("name").ThenSortByAsc("tiny")
+
+	assert.Equal(t, "Lo…▼1", model.renderHeaderTitle(model.columns[0]))
+	assert.Equal(t, "…", model.renderHeaderTitle(model.columns[1]))
+
+	model = model.SortByAsc("tiny")
+
+	assert.Equal(t, "▲", model.renderHeaderTitle(model.columns[1]))
+}
@@ -0,0 +1,448 @@
+package table
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/muesli/reflow/ansi"
+)
+
+const (
+	sqlNumberColumnWidth  = 10
+	sqlBoolColumnWidth    = 5
+	sqlTimeColumnWidth    = 19
+	sqlDefaultColumnWidth = 20
+)
+
+// sqlTimeLayouts are the layouts tried when a driver returns a timestamp as
+// text, such as SQLite.
+var sqlTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// SQLColumns returns a column for each column in the query results, titled by
+// the column name, with a width based on the column's type.  Columns are keyed
+// by the column name, except that repeated names such as from a join get a
+// numbered suffix, so the second "id" column has the key "id_2".
+func SQLColumns(rows *sql.Rows) ([]Column, error) {
+	columnTypes, err := rows.ColumnTypes()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	keys := sqlColumnKeys(columnTypes)
+	columns := make([]Column, len(columnTypes))
+
+	for i, columnType := range columnTypes {
+		columns[i] = NewColumn(keys[i], columnType.Name(), sqlColumnWidth(columnType))
+	}
+
+	return columns, nil
+}
+
+// sqlColumnKeys returns a unique key for each column, which is the column name
+// with a numbered suffix if an earlier column has the same key.
+func sqlColumnKeys(columnTypes []*sql.ColumnType) []string {
+	keys := make([]string, len(columnTypes))
+	used := make(map[string]bool, len(columnTypes))
+
+	for i, columnType := range columnTypes {
+		key := columnType.Name()
+
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s_%d", columnType.Name(), n)
+		}
+
+		keys[i] = key
+		used[key] = true
+	}
+
+	return keys
+}
+
+// NewFromSQL creates a table from all the results of a query, with columns
+// from SQLColumns that are wide enough to fit their content up to a limit.
+// Values are converted to Go types based on each column's type, such as int64
+// for integers and time.Time for timestamps, even if the driver returns them
+// as text.  NULL values are left out of the
+// row data, so they're shown with the missing data indicator set by
+// WithMissingDataIndicator.  The rows are closed when done.
+func NewFromSQL(rows *sql.Rows) (Model, error) {
+	defer rows.Close()
+
+	columns, err := SQLColumns(rows)
+
+	if err != nil {
+		return Model{}, err
+	}
+
+	scanner, err := newSQLRowScanner(rows)
+
+	if err != nil {
+		return Model{}, err
+	}
+
+	tableRows := []Row{}
+
+	for rows.Next() {
+		row, err := scanner.scan()
+
+		if err != nil {
+			return Model{}, err
+		}
+
+		tableRows = append(tableRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return Model{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	for i := range columns {
+		columns[i] = columns[i].withLoadedWidth(tableRows)
+	}
+
+	return New(columns).WithRows(tableRows), nil
+}
+
+// SQLRowSource is a RowSource that streams the results of a query as they're
+// needed, for results that are too large to read all at once.  Since query
+// results can only be read forwards, rows that have been read are kept, and
+// the row count grows as more rows are read.  Sorting and filtering aren't
+// supported, so they should be done in the query instead.
+//
+// Use it with WithPageSize so that rows are read a page at a time as the user
+// pages down.  Create with NewSQLRowSource.
+type SQLRowSource struct {
+	mu sync.Mutex
+
+	rows      *sql.Rows
+	scanner   sqlRowScanner
+	columns   []Column
+	readAhead int
+
+	read []Row
+	done bool
+	err  error
+}
+
+// NewSQLRowSource creates a row source that streams the given query results,
+// reading readAhead rows beyond any page that's fetched so that the next page
+// is always known to exist.  This is usually the page size.  If readAhead is 0
+// or less, all rows are read on the first fetch.  The rows are closed once
+// they've all been read, or by Close.
+func NewSQLRowSource(rows *sql.Rows, readAhead int) (*SQLRowSource, error) {
+	columns, err := SQLColumns(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	scanner, err := newSQLRowScanner(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLRowSource{
+		rows:      rows,
+		scanner:   scanner,
+		columns:   columns,
+		readAhead: readAhead,
+	}, nil
+}
+
+// Columns returns the columns for the query results, from SQLColumns.
+func (s *SQLRowSource) Columns() []Column {
+	columns := make([]Column, len(s.columns))
+	copy(columns, s.columns)
+
+	return columns
+}
+
+// RowCount returns the number of rows read so far, after reading ahead.  If
+// not all rows have been read, this is less than the total number of rows.
+func (s *SQLRowSource) RowCount() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.readTo(s.readAhead); err != nil {
+		return 0, err
+	}
+
+	return len(s.read), nil
+}
+
+// FetchRows returns up to limit rows starting at the given offset, reading
+// more rows from the query results if needed.
+func (s *SQLRowSource) FetchRows(offset, limit int) ([]Row, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.readTo(offset + limit + s.readAhead); err != nil {
+		return nil, err
+	}
+
+	if offset >= len(s.read) {
+		return nil, nil
+	}
+
+	end := min(offset+limit, len(s.read))
+	rows := make([]Row, end-offset)
+	copy(rows, s.read[offset:end])
+
+	return rows, nil
+}
+
+// Close stops reading the query results.  Rows that were already read can
+// still be fetched.
+func (s *SQLRowSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.done = true
+
+	return s.rows.Close()
+}
+
+// rowCountGrows tells the table to fetch the row count again after fetching
+// rows, since reading more rows can increase it.
+func (s *SQLRowSource) rowCountGrows() {}
+
+// readTo reads rows until the given number have been read or there are no
+// more, where a count of 0 or less reads all rows.
+func (s *SQLRowSource) readTo(count int) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	for !s.done && (s.readAhead <= 0 || len(s.read) < count) {
+		if !s.rows.Next() {
+			s.done = true
+			s.err = s.rows.Err()
+
+			if closeErr := s.rows.Close(); s.err == nil {
+				s.err = closeErr
+			}
+
+			if s.err != nil {
+				s.err = fmt.Errorf("failed to read rows: %w", s.err)
+			}
+
+			return s.err
+		}
+
+		row, err := s.scanner.scan()
+
+		if err != nil {
+			s.err = err
+
+			return err
+		}
+
+		// IDs keep selections when a page is fetched again
+		s.read = append(s.read, row.WithID(strconv.Itoa(len(s.read))))
+	}
+
+	return nil
+}
+
+// sqlRowScanner scans query results into rows.
+type sqlRowScanner struct {
+	rows        *sql.Rows
+	columnTypes []*sql.ColumnType
+	keys        []string
+}
+
+func newSQLRowScanner(rows *sql.Rows) (sqlRowScanner, error) {
+	columnTypes, err := rows.ColumnTypes()
+
+	if err != nil {
+		return sqlRowScanner{}, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	return sqlRowScanner{
+		rows:        rows,
+		columnTypes: columnTypes,
+		keys:        sqlColumnKeys(columnTypes),
+	}, nil
+}
+
+// scan reads the current result into a row, leaving out NULL values.
+func (s sqlRowScanner) scan() (Row, error) {
+	values := make([]any, len(s.columnTypes))
+	pointers := make([]any, len(values))
+
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := s.rows.Scan(pointers...); err != nil {
+		return Row{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	data := RowData{}
+
+	for i, columnType := range s.columnTypes {
+		if values[i] == nil {
+			continue
+		}
+
+		data[s.keys[i]] = convertSQLValue(values[i], columnType)
+	}
+
+	return NewRow(data), nil
+}
+
+// sqlValueKind returns the kind of Go value that the column holds, looking
+// through nullable types such as sql.NullInt64.
+func sqlValueKind(columnType *sql.ColumnType) (reflect.Kind, bool) {
+	scanType := columnType.ScanType()
+
+	if scanType == nil {
+		return reflect.Invalid, false
+	}
+
+	for scanType.Kind() == reflect.Pointer {
+		scanType = scanType.Elem()
+	}
+
+	if scanType == reflect.TypeOf(time.Time{}) || scanType == reflect.TypeOf(sql.NullTime{}) {
+		return reflect.Struct, true
+	}
+
+	// Nullable types such as sql.NullInt64 hold the value in their first field
+	if scanType.Kind() == reflect.Struct && strings.HasPrefix(scanType.Name(), "Null") && scanType.NumField() > 0 {
+		scanType = scanType.Field(0).Type
+	}
+
+	return scanType.Kind(), true
+}
+
+// convertSQLValue converts a value scanned from the driver to the Go type that
+// matches the column, since some drivers return text for every type.
+//
+//nolint:cyclop
+func convertSQLValue(value any, columnType *sql.ColumnType) any {
+	var text string
+
+	switch value := value.(type) {
+	case []byte:
+		if isSQLBinaryType(columnType.DatabaseTypeName()) {
+			return value
+		}
+
+		text = string(value)
+
+	case string:
+		text = value
+
+	default:
+		return value
+	}
+
+	kind, ok := sqlValueKind(columnType)
+
+	if kind == reflect.Struct || isSQLTimeType(columnType.DatabaseTypeName()) {
+		return parseSQLTime(text)
+	}
+
+	if !ok {
+		return text
+	}
+
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if parsed, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return parsed
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if parsed, err := strconv.ParseUint(text, 10, 64); err == nil {
+			return parsed
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if parsed, err := strconv.ParseFloat(text, 64); err == nil {
+			return parsed
+		}
+
+	case reflect.Bool:
+		if parsed, err := strconv.ParseBool(text); err == nil {
+			return parsed
+		}
+	}
+
+	return text
+}
+
+// parseSQLTime converts a timestamp that a driver returned as text to a
+// time.Time, or returns the text if it's not in a known layout.
+func parseSQLTime(text string) any {
+	for _, layout := range sqlTimeLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed
+		}
+	}
+
+	return text
+}
+
+// isSQLTimeType returns true if the database type holds a date or timestamp.
+func isSQLTimeType(databaseType string) bool {
+	databaseType = strings.ToUpper(databaseType)
+
+	return strings.HasPrefix(databaseType, "TIMESTAMP") ||
+		databaseType == "DATETIME" ||
+		databaseType == "DATE"
+}
+
+// isSQLBinaryType returns true if the database type holds raw bytes rather
+// than text.
+func isSQLBinaryType(databaseType string) bool {
+	databaseType = strings.ToUpper(databaseType)
+
+	return strings.Contains(databaseType, "BLOB") ||
+		strings.Contains(databaseType, "BINARY") ||
+		databaseType == "BYTEA"
+}
+
+// sqlColumnWidth returns a width for the column based on its type, since the
+// values aren't known ahead of time.
+func sqlColumnWidth(columnType *sql.ColumnType) int {
+	width := sqlDefaultColumnWidth
+
+	if length, ok := columnType.Length(); ok && length > 0 {
+		width = loaderMaxColumnWidth
+
+		if length < loaderMaxColumnWidth {
+			width = int(length)
+		}
+	} else if kind, ok := sqlValueKind(columnType); ok {
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			width = sqlNumberColumnWidth
+
+		case reflect.Bool:
+			width = sqlBoolColumnWidth
+
+		case reflect.Struct:
+			width = sqlTimeColumnWidth
+		}
+	}
+
+	return max(width, ansi.PrintableRuneWidth(columnType.Name()))
+}
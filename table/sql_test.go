@@ -0,0 +1,336 @@
+package table
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSQLDriver is a stand-in database driver where each query is the name of
+// a result set in fakeSQLResults.
+type fakeSQLDriver struct{}
+
+type fakeSQLColumn struct {
+	name         string
+	databaseType string
+	scanType     reflect.Type
+	length       int64
+}
+
+type fakeSQLResult struct {
+	columns []fakeSQLColumn
+	rows    [][]driver.Value
+}
+
+var fakeSQLResults = map[string]fakeSQLResult{
+	"people": {
+		columns: []fakeSQLColumn{
+			{name: "id", databaseType: "BIGINT", scanType: reflect.TypeOf(int64(0))},
+			{name: "name", databaseType: "VARCHAR", scanType: reflect.TypeOf(""), length: 12},
+			{name: "score", databaseType: "DECIMAL", scanType: reflect.TypeOf(sql.NullFloat64{})},
+			{name: "active", databaseType: "BOOL", scanType: reflect.TypeOf(false)},
+			{name: "joined", databaseType: "TIMESTAMP", scanType: reflect.TypeOf(time.Time{})},
+			{name: "avatar", databaseType: "BLOB", scanType: reflect.TypeOf([]byte{})},
+		},
+		rows: [][]driver.Value{
+			{int64(1), "Alice", []byte("9.5"), true, time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC), []byte{1, 2}},
+			{int64(2), []byte("Bob"), nil, []byte("false"), nil, nil},
+		},
+	},
+}
+
+func init() {
+	numbers := fakeSQLResult{
+		columns: []fakeSQLColumn{
+			{name: "n", databaseType: "INT", scanType: reflect.TypeOf(sql.NullInt64{})},
+		},
+	}
+
+	for i := 0; i < 7; i++ {
+		numbers.rows = append(numbers.rows, []driver.Value{[]byte(strconv.Itoa(i))})
+	}
+
+	fakeSQLResults["numbers"] = numbers
+
+	fakeSQLResults["joined"] = fakeSQLResult{
+		columns: []fakeSQLColumn{
+			{name: "id", databaseType: "INTEGER", scanType: reflect.TypeOf(int64(0))},
+			{name: "id", databaseType: "INTEGER", scanType: reflect.TypeOf(int64(0))},
+			{name: "created", databaseType: "TIMESTAMP", scanType: reflect.TypeOf(time.Time{})},
+			{name: "updated", databaseType: "DATETIME", scanType: reflect.TypeOf("")},
+		},
+		rows: [][]driver.Value{
+			{int64(1), int64(7), []byte("2022-01-02 03:04:05"), "2022-01-02T03:04:05.5Z"},
+			{int64(2), int64(8), "not a time", nil},
+		},
+	}
+
+	sql.Register("bubbletablefake", fakeSQLDriver{})
+}
+
+func (fakeSQLDriver) Open(string) (driver.Conn, error) {
+	return fakeSQLConn{}, nil
+}
+
+type fakeSQLConn struct{}
+
+func (fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	result, exists := fakeSQLResults[query]
+
+	if !exists {
+		return nil, errors.New("no such result: " + query)
+	}
+
+	return fakeSQLStmt{result: result}, nil
+}
+
+func (fakeSQLConn) Close() error {
+	return nil
+}
+
+func (fakeSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type fakeSQLStmt struct {
+	result fakeSQLResult
+}
+
+func (fakeSQLStmt) Close() error {
+	return nil
+}
+
+func (fakeSQLStmt) NumInput() int {
+	return 0
+}
+
+func (fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported")
+}
+
+func (s fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeSQLRows{result: s.result}, nil
+}
+
+type fakeSQLRows struct {
+	result fakeSQLResult
+	next   int
+}
+
+func (r *fakeSQLRows) Columns() []string {
+	names := make([]string, len(r.result.columns))
+
+	for i, column := range r.result.columns {
+		names[i] = column.name
+	}
+
+	return names
+}
+
+func (r *fakeSQLRows) Close() error {
+	return nil
+}
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.result.rows[r.next])
+	r.next++
+
+	return nil
+}
+
+func (r *fakeSQLRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.result.columns[index].scanType
+}
+
+func (r *fakeSQLRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.result.columns[index].databaseType
+}
+
+func (r *fakeSQLRows) ColumnTypeLength(index int) (int64, bool) {
+	length := r.result.columns[index].length
+
+	return length, length > 0
+}
+
+func queryFakeSQL(t *testing.T, query string) *sql.Rows {
+	t.Helper()
+
+	db, err := sql.Open("bubbletablefake", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query(query)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rows
+}
+
+func TestSQLColumns(t *testing.T) {
+	rows := queryFakeSQL(t, "people")
+	defer rows.Close()
+
+	columns, err := SQLColumns(rows)
+
+	assert.NoError(t, err)
+
+	widths := map[string]int{}
+
+	for _, column := range columns {
+		assert.Equal(t, column.key, column.title)
+		widths[column.key] = column.width
+	}
+
+	assert.Equal(t, map[string]int{"id": 10, "name": 12, "score": 10, "active": 6, "joined": 19, "avatar": 20}, widths)
+}
+
+func TestNewFromSQL(t *testing.T) {
+	model, err := NewFromSQL(queryFakeSQL(t, "people"))
+
+	assert.NoError(t, err)
+
+	rows := model.GetVisibleRows()
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, RowData{
+		"id":     int64(1),
+		"name":   "Alice",
+		"score":  9.5,
+		"active": true,
+		"joined": time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		"avatar": []byte{1, 2},
+	}, rows[0].Data)
+
+	// NULL values are missing
+	assert.Equal(t, RowData{"id": int64(2), "name": "Bob", "active": false}, rows[1].Data)
+
+	// Widths fit the content
+	assert.Equal(t, 5, model.columns[1].width)
+}
+
+func TestNewFromSQLJoinedColumnsAndTextTimes(t *testing.T) {
+	model, err := NewFromSQL(queryFakeSQL(t, "joined"))
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"id", "id_2", "created", "updated"}, model.ColumnOrder())
+	assert.Equal(t, "id", model.columns[1].title)
+
+	rows := model.GetVisibleRows()
+
+	assert.Equal(t, RowData{
+		"id":      int64(1),
+		"id_2":    int64(7),
+		"created": time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		"updated": time.Date(2022, 1, 2, 3, 4, 5, 500000000, time.UTC),
+	}, rows[0].Data)
+
+	assert.Equal(t, RowData{"id": int64(2), "id_2": int64(8), "created": "not a time"}, rows[1].Data)
+}
+
+func TestNewFromSQLMissingIndicator(t *testing.T) {
+	model, err := NewFromSQL(queryFakeSQL(t, "people"))
+
+	assert.NoError(t, err)
+
+	model = model.WithColumns(model.columns[1:3]).WithMissingDataIndicator("NULL").WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━━┳━━━━━┓
+┃ name┃score┃
+┣━━━━━╋━━━━━┫
+┃Alice┃  9.5┃
+┃  Bob┃ NULL┃
+┗━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestSQLRowSourceStreamsPages(t *testing.T) {
+	source, err := NewSQLRowSource(queryFakeSQL(t, "numbers"), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, source.Columns(), 1)
+
+	count, err := source.RowCount()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	rows, err := source.FetchRows(0, 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, int64(2), rows[2].Data["n"])
+	assert.Equal(t, "2", rows[2].ID())
+
+	count, _ = source.RowCount()
+
+	assert.Equal(t, 6, count)
+
+	rows, _ = source.FetchRows(6, 3)
+
+	assert.Len(t, rows, 1)
+
+	count, _ = source.RowCount()
+
+	assert.Equal(t, 7, count)
+
+	rows, _ = source.FetchRows(10, 3)
+
+	assert.Empty(t, rows)
+}
+
+func TestSQLRowSourceReadsAll(t *testing.T) {
+	source, err := NewSQLRowSource(queryFakeSQL(t, "numbers"), 0)
+
+	assert.NoError(t, err)
+
+	count, err := source.RowCount()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, source.Close())
+}
+
+func TestSQLRowSourceInTable(t *testing.T) {
+	source, err := NewSQLRowSource(queryFakeSQL(t, "numbers"), 3)
+
+	assert.NoError(t, err)
+
+	model := New(source.Columns()).WithRowSource(source).WithPageSize(3).Focused(true)
+
+	model, _ = model.Update(model.Init()())
+
+	assert.Equal(t, 2, model.MaxPages())
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, 2, model.CurrentPage())
+	assert.Equal(t, 3, model.MaxPages())
+	assert.Equal(t, int64(3), model.HighlightedRow().Data["n"])
+
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	model, _ = model.Update(cmd())
+
+	assert.Equal(t, 3, model.MaxPages())
+	assert.Equal(t, int64(6), model.HighlightedRow().Data["n"])
+}
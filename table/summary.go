@@ -0,0 +1,115 @@
+package table
+
+import "github.com/charmbracelet/lipgloss"
+
+// WithSummaryRow sets whether to show a summary row beneath the rows, which
+// shows the result of each column's aggregate set by Column.WithAggregate.
+// Aggregates are calculated over the visible rows after filtering, not
+// including child rows.  When using a row source, only the loaded rows are
+// summarized.
+func (m Model) WithSummaryRow(show bool) Model {
+	m.summaryRow = show
+	m.visibleRowCacheUpdated = false
+
+	if m.minimumHeight > 0 {
+		m.recalculateHeight()
+	}
+
+	return m
+}
+
+// WithSummaryStyle sets the style of the summary row shown by WithSummaryRow.
+func (m Model) WithSummaryStyle(style lipgloss.Style) Model {
+	m.summaryStyle = style
+
+	return m
+}
+
+// GetSummaryValues returns the result of each column's aggregate for the
+// summary row, keyed by column key.  Columns without an aggregate are left out.
+// Returns nil if the summary row isn't shown.
+func (m *Model) GetSummaryValues() map[string]any {
+	if !m.summaryRow {
+		return nil
+	}
+
+	if m.rowSource != nil {
+		return m.calculateSummaryValues(m.rows)
+	}
+
+	m.GetVisibleRows()
+
+	values := make(map[string]any, len(m.summaryValueCache))
+
+	for key, value := range m.summaryValueCache {
+		values[key] = value
+	}
+
+	return values
+}
+
+// calculateSummaryValues runs each column's aggregate over the given rows.
+func (m *Model) calculateSummaryValues(rows []Row) map[string]any {
+	topLevelRows := make([]Row, 0, len(rows))
+
+	for _, row := range rows {
+		if row.depth == 0 {
+			topLevelRows = append(topLevelRows, row)
+		}
+	}
+
+	values := make(map[string]any)
+
+	for _, column := range m.columns {
+		if column.aggregate != nil {
+			values[column.key] = column.aggregate(columnValues(topLevelRows, column.key))
+		}
+	}
+
+	return values
+}
+
+// summaryHeight returns the height of the summary row as rendered with or
+// without a divider above it, not including the table's bottom border, or 0 if
+// it's not shown.
+func (m Model) summaryHeight(divider bool) int {
+	if !m.summaryRow {
+		return 0
+	}
+
+	return lipgloss.Height(m.renderSummaryRow(divider)) - 1
+}
+
+// renderSummaryRow renders the summary row as the last row of the table, with
+// a divider above it if there's anything above it other than the header.
+func (m Model) renderSummaryRow(divider bool) string {
+	data := RowData{}
+	values := m.GetSummaryValues()
+
+	for _, column := range m.columns {
+		if value, exists := values[column.key]; exists {
+			data[column.key] = column.formatSummary(value)
+		}
+	}
+
+	// The summary is already formatted, and isn't part of any tree or filter
+	summaryModel := m
+	summaryModel.treeRows = false
+	summaryModel.filterMatchHighlight = false
+	summaryModel.missingDataIndicator = nil
+	summaryModel.columns = make([]Column, len(m.columns))
+
+	for i, column := range m.columns {
+		column.fmtString = ""
+		column.formatter = nil
+		summaryModel.columns[i] = column
+	}
+
+	_, styles := m.styleRows()
+
+	if divider {
+		styles = m.border.styleRowWithTopDivider(styles, len(m.columns) == 1)
+	}
+
+	return summaryModel.renderRowDataWithBorders(NewRow(data), m.summaryStyle.Copy(), -1, styles)
+}
@@ -0,0 +1,139 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func genSummaryModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 6).WithFiltered(true).WithAggregate(AggregateCount),
+		NewColumn("cpu", "CPU", 5).WithAggregate(AggregateSum),
+		NewColumn("mem", "Mem", 5).WithFormatString("%.1f").WithAggregate(AggregateMax),
+		NewColumn("notes", "Notes", 5),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "a", "cpu": 1, "mem": 2.0, "notes": "x"}),
+		NewRow(RowData{"name": "b", "cpu": 2.5, "mem": 8.0}),
+		NewRow(RowData{"name": "c", "cpu": 3, "mem": 4.0}),
+	}).WithSummaryRow(true).WithSummaryStyle(lipgloss.NewStyle())
+}
+
+func TestSummaryValues(t *testing.T) {
+	model := genSummaryModel()
+
+	assert.Equal(t, map[string]any{"name": 3, "cpu": 6.5, "mem": 8.0}, model.GetSummaryValues())
+
+	// Only filtered rows are summarized
+	model = model.Filtered(true).WithFilterInputValue("a")
+
+	assert.Equal(t, map[string]any{"name": 1, "cpu": 1.0, "mem": 2.0}, model.GetSummaryValues())
+
+	// Updated with the visible rows
+	model = model.InsertRows(NewRow(RowData{"name": "aa", "cpu": 4, "mem": 1.0}))
+
+	assert.Equal(t, map[string]any{"name": 2, "cpu": 5.0, "mem": 2.0}, model.GetSummaryValues())
+
+	model = model.WithSummaryRow(false)
+
+	assert.Nil(t, model.GetSummaryValues())
+}
+
+func TestSummaryRowView(t *testing.T) {
+	model := genSummaryModel().WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━━━┳━━━━━┳━━━━━┳━━━━━┓
+┃  Name┃  CPU┃  Mem┃Notes┃
+┣━━━━━━╋━━━━━╋━━━━━╋━━━━━┫
+┃     a┃    1┃  2.0┃    x┃
+┃     b┃  2.5┃  8.0┃     ┃
+┃     c┃    3┃  4.0┃     ┃
+┣━━━━━━╋━━━━━╋━━━━━╋━━━━━┫
+┃     3┃  6.5┃  8.0┃     ┃
+┗━━━━━━┻━━━━━┻━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestSummaryRowViewWithFooterAndNoRows(t *testing.T) {
+	model := genSummaryModel().WithRows(nil).WithStaticFooter("Footer")
+
+	const expectedTable = `┏━━━━━━┳━━━━━┳━━━━━┳━━━━━┓
+┃  Name┃  CPU┃  Mem┃Notes┃
+┣━━━━━━╋━━━━━╋━━━━━╋━━━━━┫
+┃     0┃    0┃     ┃     ┃
+┣━━━━━━┻━━━━━┻━━━━━┻━━━━━┫
+┃                  Footer┃
+┗━━━━━━━━━━━━━━━━━━━━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestSummaryRowSingleColumn(t *testing.T) {
+	model := New([]Column{
+		NewColumn("cpu", "CPU", 5).WithAggregate(AggregateAvg),
+	}).WithRows([]Row{
+		NewRow(RowData{"cpu": 1}),
+		NewRow(RowData{"cpu": 2}),
+	}).WithSummaryRow(true).WithSummaryStyle(lipgloss.NewStyle())
+
+	const expectedTable = `┏━━━━━┓
+┃  CPU┃
+┣━━━━━┫
+┃    1┃
+┃    2┃
+┣━━━━━┫
+┃  1.5┃
+┗━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestSummaryRowMinimumHeight(t *testing.T) {
+	model := genSummaryModel().WithFooterVisibility(false).WithMinimumHeight(12)
+
+	assert.Equal(t, 12, lipgloss.Height(model.View()))
+}
+
+func TestSummaryRowMinimumHeightWithoutRows(t *testing.T) {
+	model := genSummaryModel().WithRows(nil).WithFooterVisibility(false)
+
+	// Without padding above it, the summary row has no divider
+	assert.Equal(t, 5, lipgloss.Height(model.WithMinimumHeight(5).View()))
+	assert.Equal(t, 8, lipgloss.Height(model.WithMinimumHeight(8).View()))
+	assert.Equal(t, 9, lipgloss.Height(model.WithMinimumHeight(9).View()))
+}
+
+func TestAggregateMinMaxDistinct(t *testing.T) {
+	assert.Equal(t, 1, AggregateMin([]any{3, 1, 2.5, "x"}))
+	assert.Equal(t, 3, AggregateMax([]any{3, 1, 2.5, "x"}))
+	assert.Equal(t, "a", AggregateMin([]any{"b", "a", "c"}))
+	assert.Equal(t, "c", AggregateMax([]any{"b", "a", "c"}))
+	assert.Nil(t, AggregateMin(nil))
+	assert.Equal(t, 2, AggregateDistinct([]any{"a", "b", "a"}))
+}
+
+func TestSummaryRowUsesColumnFormatter(t *testing.T) {
+	model := New([]Column{
+		NewColumn("size", "Size", 7).WithCellFormatter(FormatBytes).WithAggregate(AggregateSum),
+		NewColumn("files", "Files", 5).WithCellFormatter(FormatBytes).
+			WithAggregate(AggregateCount).
+			WithSummaryFormatter(func(data any) string { return fmt.Sprintf("%v#", data) }),
+	}).WithRows([]Row{
+		NewRow(RowData{"size": 1024, "files": 1}),
+		NewRow(RowData{"size": 2048, "files": 2}),
+	}).WithSummaryRow(true).WithSummaryStyle(lipgloss.NewStyle()).WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━━━━┳━━━━━┓
+┃   Size┃Files┃
+┣━━━━━━━╋━━━━━┫
+┃  1 KiB┃  1 B┃
+┃  2 KiB┃  2 B┃
+┣━━━━━━━╋━━━━━┫
+┃  3 KiB┃   2#┃
+┗━━━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
@@ -0,0 +1,386 @@
+package table
+
+import "strings"
+
+const (
+	treeIndent         = "  "
+	treeExpandedGlyph  = "▾"
+	treeCollapsedGlyph = "▸"
+)
+
+// WithChildren sets rows that are nested beneath this row, which are shown
+// when this row is expanded.  Child rows may have children of their own.  The
+// first column of the table shows the nesting with indentation and an arrow
+// for rows that can be expanded.  Child rows aren't supported by row sources,
+// and only top level rows are matched by ID in UpdateRow, UpsertRows, and
+// DeleteRows.
+func (r Row) WithChildren(children ...Row) Row {
+	r.children = make([]Row, len(children))
+	copy(r.children, children)
+
+	return r
+}
+
+// Children returns the rows nested beneath this row.  The returned list is a
+// copy and modifications will have no effect.
+func (r Row) Children() []Row {
+	children := make([]Row, len(r.children))
+	copy(children, r.children)
+
+	return children
+}
+
+// WithExpanded sets whether the row's children are shown.
+func (r Row) WithExpanded(expanded bool) Row {
+	r.expanded = expanded
+	r.explicitState |= rowStateExpanded
+
+	return r
+}
+
+// IsExpanded returns true if the row's children are shown.
+func (r Row) IsExpanded() bool {
+	return r.expanded
+}
+
+// Depth returns how deeply nested the row is, where rows that aren't children
+// of any other row have a depth of 0.  Only set for rows returned by the table,
+// such as from GetVisibleRows or HighlightedRow.
+func (r Row) Depth() int {
+	return r.depth
+}
+
+// ExpandRowsByID expands all rows with any of the given user-defined IDs set by
+// Row.WithID, showing their children.
+func (m Model) ExpandRowsByID(ids ...string) Model {
+	return m.withRowsExpandedByID(ids, true)
+}
+
+// CollapseRowsByID collapses all rows with any of the given user-defined IDs
+// set by Row.WithID, hiding their children.
+func (m Model) CollapseRowsByID(ids ...string) Model {
+	return m.withRowsExpandedByID(ids, false)
+}
+
+// WithAllRowsExpanded expands or collapses every row that has children.
+func (m Model) WithAllRowsExpanded(expanded bool) Model {
+	m.rows, _ = mapRowTree(m.rows, func(row *Row) bool {
+		if len(row.children) == 0 || row.expanded == expanded {
+			return false
+		}
+
+		row.expanded = expanded
+
+		return true
+	})
+
+	m.visibleRowCacheUpdated = false
+	m.clampRowCursor()
+
+	return m
+}
+
+func (m Model) withRowsExpandedByID(ids []string, expanded bool) Model {
+	toChange := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		toChange[id] = true
+	}
+
+	m.rows, _ = mapRowTree(m.rows, func(row *Row) bool {
+		if row.userID == "" || !toChange[row.userID] || row.expanded == expanded {
+			return false
+		}
+
+		row.expanded = expanded
+
+		return true
+	})
+
+	m.visibleRowCacheUpdated = false
+	m.clampRowCursor()
+
+	return m
+}
+
+// rowsHaveChildren returns true if any of the rows have children.
+func rowsHaveChildren(rows []Row) bool {
+	for _, row := range rows {
+		if len(row.children) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// walkRowTree calls visit for every row in the tree, parents before children.
+func walkRowTree(rows []Row, visit func(row Row)) {
+	for _, row := range rows {
+		visit(row)
+		walkRowTree(row.children, visit)
+	}
+}
+
+// findRowInTree returns the row with the given internal ID anywhere in the tree.
+func findRowInTree(rows []Row, internalID uint32) (Row, bool) {
+	for _, row := range rows {
+		if row.id == internalID {
+			return row, true
+		}
+
+		if found, ok := findRowInTree(row.children, internalID); ok {
+			return found, true
+		}
+	}
+
+	return Row{}, false
+}
+
+// mapRowTree calls update for every row in the tree, parents before children,
+// where update returns true if it changed the row.  Returns a copy of the rows
+// with the changes, where only the lists containing changes are copied, and
+// whether anything changed at all.
+func mapRowTree(rows []Row, update func(row *Row) bool) ([]Row, bool) {
+	var updated []Row
+
+	for i := range rows {
+		row := rows[i]
+		changed := update(&row)
+
+		if children, childrenChanged := mapRowTree(row.children, update); childrenChanged {
+			row.children = children
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		if updated == nil {
+			updated = make([]Row, len(rows))
+			copy(updated, rows)
+		}
+
+		updated[i] = row
+	}
+
+	if updated == nil {
+		return rows, false
+	}
+
+	return updated, true
+}
+
+// visibleRowTree flattens the tree of rows into the rows that are shown, with
+// expanded rows followed by their children.  Siblings are sorted within their
+// parent.  While filtering, rows are shown if they or any of their descendants
+// match, with any rows that have matching descendants expanded unless the user
+// collapsed them for this filter, so that matches are always shown along with
+// their ancestors.
+func (m Model) visibleRowTree(rows []Row, filter string, sortOrder []SortColumn, depth int) []Row {
+	filtering := m.filtered && filter != ""
+	visible := make([]Row, 0, len(rows))
+
+	for _, row := range getSortedRows(sortOrder, m.columnSorts(), rows) {
+		var descendants []Row
+
+		if len(row.children) > 0 && (row.expanded || filtering) {
+			descendants = m.visibleRowTree(row.children, filter, sortOrder, depth+1)
+		}
+
+		if filtering {
+			if len(descendants) == 0 && !m.rowMatchesFilter(row, filter) {
+				continue
+			}
+
+			row.expanded = len(descendants) > 0 && !m.isFilterCollapsed(row.id, filter)
+		}
+
+		row.depth = depth
+
+		visible = append(visible, row)
+
+		if row.expanded {
+			visible = append(visible, descendants...)
+		}
+	}
+
+	return visible
+}
+
+// treeColumnKey returns the key of the column that shows the tree structure,
+// which is the first column other than the selection column.
+func (m Model) treeColumnKey() string {
+	for _, column := range m.columns {
+		if column.key != columnKeySelect {
+			return column.key
+		}
+	}
+
+	return ""
+}
+
+// treePrefix returns the indentation and expand arrow shown before the row's
+// data in the tree column.
+func treePrefix(row Row) string {
+	glyph := " "
+
+	if len(row.children) > 0 {
+		if row.expanded {
+			glyph = treeExpandedGlyph
+		} else {
+			glyph = treeCollapsedGlyph
+		}
+	}
+
+	return strings.Repeat(treeIndent, row.depth) + glyph + " "
+}
+
+// setHighlightedRowExpanded expands or collapses the highlighted row or group.
+// Trying to collapse a row that isn't expanded moves the highlight to its
+// parent row or group header instead.
+func (m *Model) setHighlightedRowExpanded(expanded bool) {
+	if m.visibleRowCount() == 0 {
+		return
+	}
+
+	highlighted := m.visibleRow(m.rowCursorIndex)
+
+	if highlighted.groupHeader != nil {
+		m.setGroupCollapsed(highlighted.groupHeader.key, !expanded)
+
+		return
+	}
+
+	if !m.treeRows && !m.isGrouped() {
+		return
+	}
+
+	current, found := findRowInTree(m.rows, highlighted.id)
+
+	if !found {
+		return
+	}
+
+	// Rows with matches are shown expanded while filtering, even if they're
+	// stored as collapsed, so go by how the row is shown
+	if !expanded && (!highlighted.expanded || len(current.children) == 0) {
+		m.highlightParentRow(highlighted.depth)
+
+		return
+	}
+
+	if m.filtered && m.appliedFilter() != "" && m.setFilterCollapsed(current, !expanded) {
+		return
+	}
+
+	if len(current.children) == 0 || current.expanded == expanded {
+		return
+	}
+
+	m.rows, _ = mapRowTree(m.rows, func(row *Row) bool {
+		if row.id != current.id {
+			return false
+		}
+
+		row.expanded = expanded
+
+		return true
+	})
+
+	m.visibleRowCacheUpdated = false
+	m.clampRowCursor()
+
+	m.appendUserEvent(UserEventRowExpandToggled{
+		RowIndex:   m.rowIndex(m.rowCursorIndex),
+		IsExpanded: expanded,
+	})
+}
+
+// setFilterCollapsed collapses or expands the row for the current filter,
+// returning true if that changed how the row is shown.  Rows are only shown
+// expanded while filtering if their descendants match, so expanding only
+// undoes an earlier collapse.
+func (m *Model) setFilterCollapsed(row Row, collapsed bool) bool {
+	filter := m.appliedFilter()
+
+	if len(row.children) == 0 || m.isFilterCollapsed(row.id, filter) == collapsed {
+		return false
+	}
+
+	filterCollapsedRows := make(map[uint32]bool, len(m.filterCollapsedRows)+1)
+
+	if m.filterCollapsedFilter == filter {
+		for id := range m.filterCollapsedRows {
+			filterCollapsedRows[id] = true
+		}
+	}
+
+	if collapsed {
+		filterCollapsedRows[row.id] = true
+	} else {
+		delete(filterCollapsedRows, row.id)
+	}
+
+	m.filterCollapsedRows = filterCollapsedRows
+	m.filterCollapsedFilter = filter
+
+	m.visibleRowCacheUpdated = false
+	m.clampRowCursor()
+
+	m.appendUserEvent(UserEventRowExpandToggled{
+		RowIndex:   m.rowIndex(m.rowCursorIndex),
+		IsExpanded: !collapsed,
+	})
+
+	return true
+}
+
+// isFilterCollapsed returns true if the user collapsed the row while the given
+// filter was applied.
+func (m *Model) isFilterCollapsed(internalID uint32, filter string) bool {
+	return m.filterCollapsedFilter == filter && m.filterCollapsedRows[internalID]
+}
+
+// highlightParentRow moves the highlight to the parent of the highlighted row,
+// which is the closest row above it that's less deeply nested.
+func (m *Model) highlightParentRow(depth int) {
+	for i := m.rowCursorIndex - 1; i >= 0; i-- {
+		if m.visibleRow(i).depth < depth {
+			m.rowCursorIndex = i
+			m.currentPage = m.expectedPageForRowIndex(i)
+
+			return
+		}
+	}
+}
+
+// refreshRowTree recalculates the visible rows after rows have been changed,
+// since rows with children can't be inserted into the visible rows in place.
+// The highlight stays on the same row if it's still visible.
+func (m *Model) refreshRowTree(highlightedInternalID uint32) {
+	m.visibleRowCacheUpdated = false
+
+	if index := m.displayIndexOfInternalID(highlightedInternalID); index != -1 {
+		m.rowCursorIndex = index
+	}
+
+	m.clampRowCursor()
+}
+
+// displayIndexOfInternalID returns the index of the displayed row with the
+// given internal ID, or -1 if it's not displayed.
+func (m *Model) displayIndexOfInternalID(internalID uint32) int {
+	if internalID == 0 {
+		return -1
+	}
+
+	for i, row := range m.displayRows() {
+		if row.id == internalID {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,284 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func genTreeModel() Model {
+	return New([]Column{
+		NewColumn("name", "Name", 16).WithFiltered(true),
+		NewColumn("count", "Count", 5),
+	}).WithRows([]Row{
+		NewRow(RowData{"name": "prod", "count": 2}).WithID("prod").WithChildren(
+			NewRow(RowData{"name": "web", "count": 2}).WithID("web").WithChildren(
+				NewRow(RowData{"name": "web-2", "count": 1}).WithID("web-2"),
+				NewRow(RowData{"name": "web-1", "count": 1}).WithID("web-1"),
+			),
+			NewRow(RowData{"name": "db", "count": 1}).WithID("db"),
+		),
+		NewRow(RowData{"name": "dev", "count": 0}).WithID("dev"),
+	}).Focused(true)
+}
+
+func visibleTreeNames(model Model) []string {
+	names := []string{}
+
+	for _, row := range model.GetVisibleRows() {
+		indent := ""
+
+		for i := 0; i < row.Depth(); i++ {
+			indent += "  "
+		}
+
+		names = append(names, indent+row.Data["name"].(string))
+	}
+
+	return names
+}
+
+func TestTreeRowsCollapsedByDefault(t *testing.T) {
+	model := genTreeModel()
+
+	assert.Equal(t, []string{"prod", "dev"}, visibleTreeNames(model))
+}
+
+func TestTreeRowsExpandByID(t *testing.T) {
+	model := genTreeModel().ExpandRowsByID("prod", "web")
+
+	assert.Equal(t, []string{"prod", "  web", "    web-2", "    web-1", "  db", "dev"}, visibleTreeNames(model))
+
+	model = model.CollapseRowsByID("web")
+
+	assert.Equal(t, []string{"prod", "  web", "  db", "dev"}, visibleTreeNames(model))
+
+	model = model.WithAllRowsExpanded(false)
+
+	assert.Equal(t, []string{"prod", "dev"}, visibleTreeNames(model))
+
+	model = model.WithAllRowsExpanded(true)
+
+	assert.Len(t, model.GetVisibleRows(), 6)
+}
+
+func TestTreeRowsExpandCollapseKeys(t *testing.T) {
+	model := genTreeModel()
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+
+	assert.Equal(t, []string{"prod", "  web", "  db", "dev"}, visibleTreeNames(model))
+	assert.Equal(t, []UserEvent{UserEventRowExpandToggled{RowIndex: 0, IsExpanded: true}}, model.GetLastUpdateUserEvents())
+
+	// Rows without children can't be expanded
+	model = model.WithHighlightedRow(2)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+
+	assert.Len(t, model.GetVisibleRows(), 4)
+	assert.Empty(t, model.GetLastUpdateUserEvents())
+
+	// Collapsing a row that isn't expanded moves to its parent
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+	assert.Len(t, model.GetVisibleRows(), 4)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+
+	assert.Equal(t, []string{"prod", "dev"}, visibleTreeNames(model))
+	assert.Equal(t, []UserEvent{UserEventRowExpandToggled{RowIndex: 0, IsExpanded: false}}, model.GetLastUpdateUserEvents())
+}
+
+func TestTreeRowsSortWithinParent(t *testing.T) {
+	model := genTreeModel().WithAllRowsExpanded(true).SortByAsc("name")
+
+	assert.Equal(t, []string{"dev", "prod", "  db", "  web", "    web-1", "    web-2"}, visibleTreeNames(model))
+
+	model = model.SortByDesc("count").ThenSortByAsc("name")
+
+	assert.Equal(t, []string{"prod", "  web", "    web-1", "    web-2", "  db", "dev"}, visibleTreeNames(model))
+}
+
+func TestTreeRowsFilterKeepsAncestors(t *testing.T) {
+	// Even when collapsed, matches should be shown
+	model := genTreeModel().Filtered(true).WithFilterInputValue("web-1")
+
+	assert.Equal(t, []string{"prod", "  web", "    web-1"}, visibleTreeNames(model))
+
+	for _, row := range model.GetVisibleRows()[:2] {
+		assert.True(t, row.IsExpanded(), "Ancestors of matches should show as expanded")
+	}
+
+	// Matching parents don't show their children unless they also match
+	model = model.WithFilterInputValue("d")
+
+	assert.Equal(t, []string{"prod", "  db", "dev"}, visibleTreeNames(model))
+
+	model = model.WithFilterInputValue("")
+
+	assert.Equal(t, []string{"prod", "dev"}, visibleTreeNames(model))
+}
+
+func TestTreeRowsCollapseWhileFiltering(t *testing.T) {
+	model := genTreeModel().Filtered(true).WithFilterInputValue("web-1").WithHighlightedRow(1)
+
+	// The row is only shown expanded because a child matches, but it should
+	// still collapse
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+
+	assert.Equal(t, 1, model.GetHighlightedRowIndex())
+	assert.Equal(t, []string{"prod", "  web"}, visibleTreeNames(model))
+	assert.False(t, model.HighlightedRow().IsExpanded())
+	assert.Equal(t, []UserEvent{UserEventRowExpandToggled{RowIndex: 1, IsExpanded: false}}, model.GetLastUpdateUserEvents())
+
+	// Collapsing again moves to the parent
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+
+	assert.Equal(t, 0, model.GetHighlightedRowIndex())
+
+	model = model.WithHighlightedRow(1)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+
+	assert.Equal(t, []string{"prod", "  web", "    web-1"}, visibleTreeNames(model))
+	assert.Equal(t, []UserEvent{UserEventRowExpandToggled{RowIndex: 1, IsExpanded: true}}, model.GetLastUpdateUserEvents())
+
+	// Collapsing only applies to the filter it was done with
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	model = model.WithFilterInputValue("web")
+
+	assert.Equal(t, []string{"prod", "  web", "    web-2", "    web-1"}, visibleTreeNames(model))
+
+	model = model.WithFilterInputValue("")
+
+	assert.Equal(t, []string{"prod", "dev"}, visibleTreeNames(model), "Stored expansion should be unchanged")
+}
+
+func TestTreeRowsView(t *testing.T) {
+	model := genTreeModel().ExpandRowsByID("prod").WithFooterVisibility(false)
+
+	const expectedTable = `┏━━━━━━━━━━━━━━━━┳━━━━━┓
+┃            Name┃Count┃
+┣━━━━━━━━━━━━━━━━╋━━━━━┫
+┃▾ prod          ┃    2┃
+┃  ▸ web         ┃    2┃
+┃    db          ┃    1┃
+┃  dev           ┃    0┃
+┗━━━━━━━━━━━━━━━━┻━━━━━┛`
+
+	assert.Equal(t, expectedTable, model.View())
+}
+
+func TestTreeRowsKeepStateAcrossWithRows(t *testing.T) {
+	model := genTreeModel().ExpandRowsByID("prod", "web").SelectRowsByID("web-1")
+
+	model = model.WithRows([]Row{
+		NewRow(RowData{"name": "prod"}).WithID("prod").WithChildren(
+			NewRow(RowData{"name": "web"}).WithID("web").WithChildren(
+				NewRow(RowData{"name": "web-1"}).WithID("web-1"),
+			),
+		),
+	})
+
+	assert.Equal(t, []string{"prod", "  web", "    web-1"}, visibleTreeNames(model))
+
+	row, found := model.RowByID("web-1")
+
+	assert.True(t, found)
+	assert.True(t, row.selected)
+}
+
+func TestTreeRowsKeepHighlightThroughWithRows(t *testing.T) {
+	rows := []Row{
+		NewRow(RowData{"name": "p"}).WithID("p").WithChildren(
+			NewRow(RowData{"name": "c1"}).WithID("c1"),
+			NewRow(RowData{"name": "c2"}).WithID("c2"),
+		),
+	}
+
+	model := New([]Column{NewColumn("name", "Name", 8)}).
+		WithRows(rows).
+		ExpandRowsByID("p").
+		HighlightRowByID("c2")
+
+	assert.Equal(t, "c2", model.HighlightedRow().Data["name"])
+
+	model = model.WithRows(rows)
+
+	assert.Equal(t, []string{"p", "  c1", "  c2"}, visibleTreeNames(model))
+	assert.Equal(t, "c2", model.HighlightedRow().Data["name"])
+	assert.Equal(t, 2, model.GetHighlightedRowIndex())
+}
+
+func TestTreeRowsSelectChildren(t *testing.T) {
+	model := genTreeModel().
+		ExpandRowsByID("prod").
+		SelectableRows(true).
+		WithHighlightedRow(2)
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	selected := model.SelectedRows()
+
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "db", selected[0].Data["name"])
+
+	// Deselecting should keep the tree as it is
+	model = model.WithAllRowsDeselected()
+
+	assert.Empty(t, model.SelectedRows())
+	assert.Len(t, model.rows, 2)
+	assert.Len(t, model.GetVisibleRows(), 4)
+}
+
+func TestTreeRowsMutate(t *testing.T) {
+	model := genTreeModel().ExpandRowsByID("prod").WithHighlightedRow(3)
+
+	assert.Equal(t, "dev", model.HighlightedRow().Data["name"])
+
+	model = model.UpsertRows(
+		NewRow(RowData{"name": "prod"}).WithID("prod").WithChildren(
+			NewRow(RowData{"name": "cache"}).WithID("cache"),
+		),
+	)
+
+	assert.Equal(t, []string{"prod", "  cache", "dev"}, visibleTreeNames(model))
+	assert.Equal(t, "dev", model.HighlightedRow().Data["name"])
+
+	model = model.InsertRows(NewRow(RowData{"name": "test"}))
+
+	assert.Equal(t, []string{"prod", "  cache", "dev", "test"}, visibleTreeNames(model))
+
+	model = model.DeleteRows("prod")
+
+	assert.Equal(t, []string{"dev", "test"}, visibleTreeNames(model))
+	assert.Equal(t, "dev", model.HighlightedRow().Data["name"])
+}
+
+func TestMapRowTreeOnlyCopiesChanges(t *testing.T) {
+	rows := []Row{
+		NewRow(RowData{"name": "a"}).WithChildren(NewRow(RowData{"name": "b"})),
+		NewRow(RowData{"name": "c"}),
+	}
+
+	updated, changed := mapRowTree(rows, func(row *Row) bool {
+		return false
+	})
+
+	assert.False(t, changed)
+	assert.Equal(t, rows, updated)
+
+	updated, changed = mapRowTree(rows, func(row *Row) bool {
+		if row.Data["name"] != "b" {
+			return false
+		}
+
+		row.selected = true
+
+		return true
+	})
+
+	assert.True(t, changed)
+	assert.True(t, updated[0].children[0].selected)
+	assert.False(t, rows[0].children[0].selected, "Original rows should not be modified")
+}
@@ -9,7 +9,7 @@ func (m *Model) moveHighlightUp() {
 	m.rowCursorIndex--
 
 	if m.rowCursorIndex < 0 {
-		m.rowCursorIndex = len(m.GetVisibleRows()) - 1
+		m.rowCursorIndex = m.visibleRowCount() - 1
 	}
 
 	m.currentPage = m.expectedPageForRowIndex(m.rowCursorIndex)
@@ -18,7 +18,7 @@ func (m *Model) moveHighlightUp() {
 func (m *Model) moveHighlightDown() {
 	m.rowCursorIndex++
 
-	if m.rowCursorIndex >= len(m.GetVisibleRows()) {
+	if m.rowCursorIndex >= m.visibleRowCount() {
 		m.rowCursorIndex = 0
 	}
 
@@ -26,27 +26,35 @@ func (m *Model) moveHighlightDown() {
 }
 
 func (m *Model) toggleSelect() {
-	if !m.selectableRows || len(m.GetVisibleRows()) == 0 {
+	if !m.selectableRows || m.visibleRowCount() == 0 {
 		return
 	}
 
-	rows := m.GetVisibleRows()
+	highlighted := m.visibleRow(m.rowCursorIndex)
 
-	rowID := rows[m.rowCursorIndex].id
+	if highlighted.groupHeader != nil {
+		return
+	}
+
+	rowID := highlighted.id
 
 	currentSelectedState := false
 
-	for i := range m.rows {
-		if m.rows[i].id == rowID {
-			currentSelectedState = m.rows[i].selected
-			m.rows[i].selected = !m.rows[i].selected
+	m.rows, _ = mapRowTree(m.rows, func(row *Row) bool {
+		if row.id != rowID {
+			return false
 		}
-	}
+
+		currentSelectedState = row.selected
+		row.selected = !row.selected
+
+		return true
+	})
 
 	m.visibleRowCacheUpdated = false
 
 	m.appendUserEvent(UserEventRowSelectToggled{
-		RowIndex:   m.rowCursorIndex,
+		RowIndex:   m.rowIndex(m.rowCursorIndex),
 		IsSelected: !currentSelectedState,
 	})
 }
@@ -61,15 +69,66 @@ func (m Model) updateFilterTextInput(msg tea.Msg) (Model, tea.Cmd) {
 	}
 	m.filterTextInput, cmd = m.filterTextInput.Update(msg)
 	m.pageFirst()
-	m.visibleRowCacheUpdated = false
+	m.invalidateQuery()
 
 	return m, cmd
 }
 
+func (m *Model) handleCellCursorKeypress(msg tea.KeyMsg) {
+	previous, ok := m.cursorColumn()
+
+	if !ok {
+		return
+	}
+
+	if key.Matches(msg, m.keyMap.CellLeft) {
+		m.moveColumnCursor(-1)
+	}
+
+	if key.Matches(msg, m.keyMap.CellRight) {
+		m.moveColumnCursor(1)
+	}
+
+	if current, _ := m.cursorColumn(); current.key != previous.key {
+		m.appendUserEvent(UserEventHighlightedColumnChanged{
+			PreviousColumnKey: previous.key,
+			ColumnKey:         current.key,
+		})
+	}
+}
+
+func (m *Model) handleColumnCursorKeypress(msg tea.KeyMsg) {
+	if key.Matches(msg, m.keyMap.SortColumnNext) {
+		m.moveColumnCursor(1)
+	}
+
+	if key.Matches(msg, m.keyMap.SortColumnPrev) {
+		m.moveColumnCursor(-1)
+	}
+}
+
+func (m *Model) handleSortKeypress(msg tea.KeyMsg) {
+	column, ok := m.cursorColumn()
+
+	if !ok {
+		return
+	}
+
+	if key.Matches(msg, m.keyMap.SortCycle) {
+		m.cycleSort(column.key, false)
+	}
+
+	if key.Matches(msg, m.keyMap.SortCycleAdd) {
+		m.cycleSort(column.key, true)
+	}
+}
+
 // This is a series of Matches tests with minimal logic
 //
 //nolint:cyclop
-func (m *Model) handleKeypress(msg tea.KeyMsg) {
+func (m *Model) handleKeypress(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
 	previousRowIndex := m.rowCursorIndex
 
 	if key.Matches(msg, m.keyMap.RowDown) {
@@ -84,6 +143,18 @@ func (m *Model) handleKeypress(msg tea.KeyMsg) {
 		m.toggleSelect()
 	}
 
+	if key.Matches(msg, m.keyMap.RowExpand) {
+		m.setHighlightedRowExpanded(true)
+	}
+
+	if key.Matches(msg, m.keyMap.RowCollapse) {
+		m.setHighlightedRowExpanded(false)
+	}
+
+	if key.Matches(msg, m.keyMap.RowDetailToggle) {
+		m.toggleHighlightedRowDetail()
+	}
+
 	if key.Matches(msg, m.keyMap.PageDown) {
 		m.pageDown()
 	}
@@ -100,13 +171,13 @@ func (m *Model) handleKeypress(msg tea.KeyMsg) {
 		m.pageLast()
 	}
 
-	if key.Matches(msg, m.keyMap.Filter) {
+	if !m.rowSourceIgnoresFilter() && key.Matches(msg, m.keyMap.Filter) {
 		m.filterTextInput.Focus()
 		m.appendUserEvent(UserEventFilterInputFocused{})
 	}
 
 	if key.Matches(msg, m.keyMap.FilterClear) {
-		m.visibleRowCacheUpdated = false
+		m.invalidateQuery()
 		m.filterTextInput.Reset()
 	}
 
@@ -118,22 +189,104 @@ func (m *Model) handleKeypress(msg tea.KeyMsg) {
 		m.scrollLeft()
 	}
 
+	if m.exportOpen != nil && key.Matches(msg, m.keyMap.Export) {
+		cmd = m.exportCmd()
+	}
+
+	if copyCmd := m.handleCopyKeypress(msg); copyCmd != nil {
+		cmd = copyCmd
+	}
+
+	if m.cellCursor {
+		m.handleCellCursorKeypress(msg)
+
+		if key.Matches(msg, m.keyMap.CellEditStart) {
+			cmd = m.startCellEdit()
+		}
+	}
+
+	if m.interactiveSorting || m.columnResizing || m.columnArranging {
+		m.handleColumnCursorKeypress(msg)
+	}
+
+	if m.interactiveSorting {
+		m.handleSortKeypress(msg)
+	}
+
+	if m.columnResizing {
+		m.handleColumnResizeKeypress(msg)
+	}
+
+	if m.columnArranging {
+		m.handleColumnArrangeKeypress(msg)
+	}
+
 	if m.rowCursorIndex != previousRowIndex {
 		m.appendUserEvent(UserEventHighlightedIndexChanged{
-			PreviousRowIndex: previousRowIndex,
-			SelectedRowIndex: m.rowCursorIndex,
+			PreviousRowIndex: m.rowIndex(previousRowIndex),
+			SelectedRowIndex: m.rowIndex(m.rowCursorIndex),
 		})
 	}
+
+	return cmd
 }
 
 // Update responds to input from the user or other messages from Bubble Tea.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+
+	// Any changes such as paging or sorting may need new rows from the source
+	// or new results from background filtering
+	return m, batchCmds(cmd, m.loadRowSourceCmd(), m.asyncFilterCmd())
+}
+
+// batchCmds is like tea.Batch, but returns single commands as they are so that
+// they're simpler to run directly.
+func batchCmds(cmds ...tea.Cmd) tea.Cmd {
+	validCmds := []tea.Cmd{}
+
+	for _, cmd := range cmds {
+		if cmd != nil {
+			validCmds = append(validCmds, cmd)
+		}
+	}
+
+	switch len(validCmds) {
+	case 0:
+		return nil
+
+	case 1:
+		return validCmds[0]
+
+	default:
+		return tea.Batch(validCmds...)
+	}
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	m.clearUserEvents()
 
+	switch msg := msg.(type) {
+	case rowSourceLoadedMsg:
+		m.handleRowSourceLoaded(msg)
+
+		return m, nil
+
+	case asyncFilterDebounceMsg:
+		return m, m.handleAsyncFilterDebounce(msg)
+
+	case asyncFilterResultMsg:
+		return m, m.handleAsyncFilterResult(msg)
+	}
+
 	if !m.focused {
 		return m, nil
 	}
 
+	if m.cellEditor != nil {
+		return m.updateCellEditor(msg)
+	}
+
 	if m.filterTextInput.Focused() {
 		var cmd tea.Cmd
 		m, cmd = m.updateFilterTextInput(msg)
@@ -145,10 +298,23 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.columnChooserOpen {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			m.handleColumnChooserKeypress(msg)
+		}
+
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		m.handleKeypress(msg)
+		cmd = m.handleKeypress(msg)
+
+	case tea.MouseMsg:
+		m.handleMouse(msg)
 	}
 
-	return m, nil
+	return m, cmd
 }
@@ -6,60 +6,193 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+type viewSectionKind int
+
+const (
+	viewSectionHeader viewSectionKind = iota
+	viewSectionPinned
+	viewSectionRow
+	viewSectionDetail
+	viewSectionPadding
+	viewSectionSummary
+	viewSectionFooter
+)
+
+// viewSection is a single rendered piece of the table, tagged with what it is
+// so that screen positions can be mapped back to table elements.
+type viewSection struct {
+	kind     viewSectionKind
+	rowIndex int
+	rendered string
+}
+
 // View renders the table. It does not end in a newline, so that it can be
 // composed with other elements more consistently.
+func (m Model) View() string {
+	sections := m.renderSections()
+
+	if len(sections) == 0 {
+		return ""
+	}
+
+	rowStrs := make([]string, 0, len(sections))
+
+	for _, section := range sections {
+		rowStrs = append(rowStrs, section.rendered)
+	}
+
+	body := strings.Builder{}
+
+	body.WriteString(lipgloss.JoinVertical(lipgloss.Left, rowStrs...))
+
+	if m.columnChooserOpen {
+		return m.renderColumnChooser(body.String())
+	}
+
+	return body.String()
+}
+
+// viewRowsPlan describes the rows on the current page and what surrounds them,
+// which decides where every section of the table goes vertically.
+type viewRowsPlan struct {
+	startRowIndex int
+	endRowIndex   int
+	numRows       int
+
+	// Detail panel content by display index, for rows that have one
+	details map[int]string
+
+	padding        int
+	summaryDivider bool
+
+	// Anything beneath the rows means no row is the last one
+	hasRowsBeneath bool
+}
+
+// planViewRows works out the rows, detail panels, and padding that are shown
+// on the current page.
+func (m Model) planViewRows() viewRowsPlan {
+	plan := viewRowsPlan{
+		details: make(map[int]string),
+	}
+
+	plan.startRowIndex, plan.endRowIndex = m.VisibleIndices()
+	plan.numRows = plan.endRowIndex - plan.startRowIndex + 1
+
+	detailHeight := 0
+
+	for i := plan.startRowIndex; i <= plan.endRowIndex; i++ {
+		if content := m.rowDetailContent(i); content != "" {
+			plan.details[i] = content
+			detailHeight += lipgloss.Height(content)
+		}
+	}
+
+	plan.summaryDivider = plan.numRows > 0 || m.hasPinnedRows()
+	plan.padding = m.calculatePadding(plan.numRows + detailHeight + m.summaryHeight(plan.summaryDivider))
+
+	// Padding also puts a divider above the summary row, which takes a line
+	if plan.padding > 0 && m.summaryRow && !plan.summaryDivider {
+		plan.padding = max(1, plan.padding-1)
+	}
+
+	plan.hasRowsBeneath = m.summaryRow || len(m.pinnedRowsBottom) > 0
+
+	return plan
+}
+
+// hasHeaderSection returns true if anything of the header is shown, which is
+// only the top border if the header is hidden.
+func (m Model) hasHeaderSection(plan viewRowsPlan) bool {
+	return m.headerVisible || plan.numRows > 0 || plan.padding > 0 || m.hasPinnedRows()
+}
+
+// renderSections renders each vertical piece of the table in order from top
+// to bottom.
 //
 //nolint:cyclop
-func (m Model) View() string {
+func (m Model) renderSections() []viewSection {
 	// Safety valve for empty tables
 	if len(m.columns) == 0 {
-		return ""
+		return nil
 	}
 
-	body := strings.Builder{}
+	m = m.withParsedFilterQuery(m.appliedFilter())
 
-	rowStrs := make([]string, 0, 1)
+	sections := make([]viewSection, 0, 1)
 
 	headers := m.renderHeaders()
 
-	startRowIndex, endRowIndex := m.VisibleIndices()
-	numRows := endRowIndex - startRowIndex + 1
-
-	padding := m.calculatePadding(numRows)
+	plan := m.planViewRows()
 
 	if m.headerVisible {
-		rowStrs = append(rowStrs, headers)
-	} else if numRows > 0 || padding > 0 {
+		sections = append(sections, viewSection{kind: viewSectionHeader, rendered: headers})
+	} else if m.hasHeaderSection(plan) {
 		//nolint: mnd // This is just getting the first newlined substring
 		split := strings.SplitN(headers, "\n", 2)
-		rowStrs = append(rowStrs, split[0])
+		sections = append(sections, viewSection{kind: viewSectionHeader, rendered: split[0]})
+	}
+
+	for i, row := range m.pinnedRowsTop {
+		last := i == len(m.pinnedRowsTop)-1 && plan.numRows == 0 && plan.padding == 0 && !plan.hasRowsBeneath
+
+		sections = append(sections, viewSection{
+			kind:     viewSectionPinned,
+			rendered: m.renderPinnedRow(row, last),
+		})
+	}
+
+	for i := plan.startRowIndex; i <= plan.endRowIndex; i++ {
+		last := plan.padding == 0 && i == plan.endRowIndex && !plan.hasRowsBeneath
+		detail, hasDetail := plan.details[i]
+
+		sections = append(sections, viewSection{
+			kind:     viewSectionRow,
+			rowIndex: i,
+			rendered: m.renderRow(i, last && !hasDetail),
+		})
+
+		if hasDetail {
+			sections = append(sections, viewSection{
+				kind:     viewSectionDetail,
+				rowIndex: i,
+				rendered: m.renderRowDetail(detail, last),
+			})
+		}
+	}
+
+	for i := 1; i <= plan.padding; i++ {
+		sections = append(sections, viewSection{
+			kind:     viewSectionPadding,
+			rendered: m.renderBlankRow(i == plan.padding && !plan.hasRowsBeneath),
+		})
 	}
 
-	for i := startRowIndex; i <= endRowIndex; i++ {
-		rowStrs = append(rowStrs, m.renderRow(i, padding == 0 && i == endRowIndex))
+	for i, row := range m.pinnedRowsBottom {
+		sections = append(sections, viewSection{
+			kind:     viewSectionPinned,
+			rendered: m.renderPinnedRow(row, i == len(m.pinnedRowsBottom)-1 && !m.summaryRow),
+		})
 	}
 
-	for i := 1; i <= padding; i++ {
-		rowStrs = append(rowStrs, m.renderBlankRow(i == padding))
+	if m.summaryRow {
+		sections = append(sections, viewSection{
+			kind:     viewSectionSummary,
+			rendered: m.renderSummaryRow(plan.summaryDivider || plan.padding > 0),
+		})
 	}
 
 	var footer string
 
-	if len(rowStrs) > 0 {
-		footer = m.renderFooter(lipgloss.Width(rowStrs[0]), false)
+	if len(sections) > 0 {
+		footer = m.renderFooter(lipgloss.Width(sections[0].rendered), false)
 	} else {
 		footer = m.renderFooter(lipgloss.Width(headers), true)
 	}
 
 	if footer != "" {
-		rowStrs = append(rowStrs, footer)
-	}
-
-	if len(rowStrs) == 0 {
-		return ""
+		sections = append(sections, viewSection{kind: viewSectionFooter, rendered: footer})
 	}
 
-	body.WriteString(lipgloss.JoinVertical(lipgloss.Left, rowStrs...))
-
-	return body.String()
+	return sections
 }